@@ -4,18 +4,28 @@ import (
 	"regexp"
 
 	"code.rocket9labs.com/tslocum/netris/pkg/event"
+	"code.rocket9labs.com/tslocum/netris/pkg/game"
 )
 
 type appConfig struct {
-	Input  map[event.GameAction][]string // Keybinds
-	Colors map[event.GameColor]string
-	Name   string
+	Input      map[event.GameAction][]string // Keybinds
+	Colors     map[event.GameColor]string
+	Name       string
+	NextPieces int // Number of upcoming pieces previewed
+
+	DAS int // Delayed auto shift in milliseconds
+	ARR int // Auto repeat rate in milliseconds, 0 moves instantly
+	SDF int // Soft drop factor, multiplies the speed of falling pieces
 }
 
 var config = &appConfig{
-	Input:  make(map[event.GameAction][]string),
-	Colors: make(map[event.GameColor]string),
-	Name:   "Anonymous",
+	Input:      make(map[event.GameAction][]string),
+	Colors:     make(map[event.GameColor]string),
+	Name:       "Anonymous",
+	NextPieces: game.DefaultNextPieces,
+	DAS:        DefaultDAS,
+	ARR:        DefaultARR,
+	SDF:        DefaultSDF,
 }
 
 var regexpColor = regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{6})$`)
@@ -7,6 +7,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,6 +38,8 @@ var (
 
 	renderLock   = new(sync.Mutex)
 	renderBuffer bytes.Buffer
+	sideBuffer   bytes.Buffer
+	targetMatrix *mino.Matrix // Matrix of the opponent receiving garbage
 
 	multiplayerMatrixSize int
 	screenPadding         int
@@ -56,18 +59,32 @@ var (
 	buttonGhostPiece       *cview.Button
 	buttonKeybindRotateCCW *cview.Button
 	buttonKeybindRotateCW  *cview.Button
+	buttonKeybindRotate180 *cview.Button
 	buttonKeybindMoveLeft  *cview.Button
 	buttonKeybindMoveRight *cview.Button
 	buttonKeybindSoftDrop  *cview.Button
 	buttonKeybindHardDrop  *cview.Button
+	buttonKeybindHold      *cview.Button
+	buttonKeybindTarget    *cview.Button
+	buttonKeybindFumen     *cview.Button
+	buttonKeybindRetry     *cview.Button
 	buttonKeybindCancel    *cview.Button
 	buttonKeybindSave      *cview.Button
 
 	buttonNewGameCancel *cview.Button
 	buttonNewGameStart  *cview.Button
+
+	buttonPracticeCancel *cview.Button
+	buttonPracticeStart  *cview.Button
 )
 
-const DefaultStatusText = "Press Enter to chat, Z/X to rotate, arrow keys or HJKL to move/drop"
+// Width of the hold box in blocks, widened to fit minos of higher ranks
+var holdWidth = 4
+
+// Time between redraws of the mode timer
+const modeTimerRefresh = 50 * time.Millisecond
+
+const DefaultStatusText = "Press Enter to chat, Z/X/A to rotate, C to hold, arrow keys or HJKL to move/drop"
 
 var (
 	renderHLine    []byte
@@ -78,6 +95,9 @@ var (
 	renderURCorner []byte
 	renderLLCorner []byte
 	renderLRCorner []byte
+
+	renderMeter        []byte // Pending garbage which may land
+	renderMeterWaiting []byte // Pending garbage which is delayed
 )
 
 func setBorderColor(color string) {
@@ -94,6 +114,11 @@ func setBorderColor(color string) {
 	renderLRCorner = doubleChar
 }
 
+func setMeterColors(color string, waitingColor string) {
+	renderMeter = []byte(fmt.Sprintf("[-:%s]  [-:-]", color))
+	renderMeterWaiting = []byte(fmt.Sprintf("[-:%s]  [-:-]", waitingColor))
+}
+
 func resetPlayerSettingsForm() {
 	playerSettingsNameInput.SetText(config.Name)
 }
@@ -101,6 +126,7 @@ func resetPlayerSettingsForm() {
 // BS 1: 10x10
 // BS 2: 20x20
 // BS 3: 40x40
+// (Standard 10x20 matrix)
 func handleResize(width int, height int) {
 	if width == screenW && height == screenH {
 		return
@@ -108,10 +134,21 @@ func handleResize(width int, height int) {
 
 	screenW, screenH = width, height
 
+	resize()
+}
+
+// resize lays out the game screen for the current screen size and game.
+func resize() {
+	matrixW, matrixH := mino.DefaultWidth, mino.DefaultHeight
+	if activeGame != nil {
+		matrixW, matrixH = activeGame.Rules.Size()
+	}
+	extraW, extraH := matrixW-mino.DefaultWidth, matrixH-mino.DefaultHeight
+
 	if !fixedBlockSize {
-		if screenW >= 106 && screenH >= 46 {
+		if screenW >= 106+(extraW*4) && screenH >= 46+(extraH*2) {
 			blockSize = 3
-		} else if screenW >= 56 && screenH >= 24 {
+		} else if screenW >= 56+(extraW*2) && screenH >= 24+extraH {
 			blockSize = 2
 		} else {
 			blockSize = 1
@@ -126,11 +163,11 @@ func handleResize(width int, height int) {
 	}
 
 	if blockSize == 1 {
-		mainHeight = 10 + 3
+		mainHeight = (matrixH / 2) + 3
 	} else if blockSize == 2 {
-		mainHeight = 20 + 3
+		mainHeight = matrixH + 3
 	} else {
-		mainHeight = 40 + 3
+		mainHeight = (matrixH * 2) + 3
 	}
 
 	if screenH > mainHeight+9 {
@@ -161,7 +198,18 @@ func handleResize(width int, height int) {
 		previewWidth = 18
 	}
 
-	multiplayerMatrixSize = ((screenW - screenPadding) - ((10 * xMultiplier) + previewWidth + 6)) / ((10 * xMultiplier) + 6)
+	holdWidth = 4
+	if activeGame != nil && activeGame.Rank > holdWidth {
+		holdWidth = activeGame.Rank
+
+		if w := (activeGame.Rank * xMultiplier) + 2; w > previewWidth {
+			previewWidth = w
+		}
+	}
+
+	holdBoxWidth := (holdWidth * xMultiplier) + 1
+
+	multiplayerMatrixSize = ((screenW - screenPadding) - ((matrixW * xMultiplier) + holdBoxWidth + previewWidth + 6)) / ((matrixW * xMultiplier) + holdBoxWidth + 6)
 
 	newLogLines = ((screenH - mainHeight) - inputHeight) - screenPadding
 	if newLogLines > 0 {
@@ -171,7 +219,7 @@ func handleResize(width int, height int) {
 	}
 
 	gameGrid.SetRows(screenPadding, mainHeight, inputHeight, -1)
-	gameGrid.SetColumns(screenPadding+1, 5+(10*xMultiplier), previewWidth, -1)
+	gameGrid.SetColumns(screenPadding+1, 5+holdBoxWidth+(matrixW*xMultiplier), previewWidth, -1)
 
 	draw <- event.DrawAll
 }
@@ -263,14 +311,18 @@ func renderPlayerGUI() {
 	player := g.Players[g.LocalPlayer]
 	m := g.Players[g.LocalPlayer].Matrix
 
-	p := mino.NewPiece(m.Bag.Next(), mino.Point{0, 0})
+	// Puzzles may have fewer pieces remaining than there are previews
+	next := m.Bag.Peek(len(player.Previews))
+	for i, preview := range player.Previews {
+		preview.Clear()
 
-	player.Preview.Clear()
+		if i < len(next) && !player.Matrix.GameOver {
+			p := mino.NewPiece(next[i], mino.Point{0, 0})
 
-	if !player.Matrix.GameOver {
-		err := player.Preview.Add(p, p.Solid, mino.Point{0, 0}, false)
-		if err != nil {
-			log.Fatalf("failed to render preview matrix: failed to add preview piece: %+v", err)
+			err := preview.Add(p, p.Solid, mino.Point{0, 0}, false)
+			if err != nil {
+				log.Fatalf("failed to render preview matrix: failed to add preview piece: %+v", err)
+			}
 		}
 	}
 
@@ -290,9 +342,16 @@ func renderPlayerGUI() {
 	}
 
 	renderLock.Lock()
-	renderMatrixes([]*mino.Matrix{g.Players[g.LocalPlayer].Preview})
+	sideBuffer.Reset()
+	for _, preview := range player.Previews {
+		renderMatrixes([]*mino.Matrix{preview})
+		sideBuffer.Write(renderBuffer.Bytes())
+	}
+	renderBuffer.Reset()
 
-	if blockSize == 1 {
+	if len(player.Previews) > 1 {
+		renderBuffer.WriteString(fmt.Sprintf("\nCombo   %d\nTimer   %.0f\nPending %d\nSpeed %s", combo, comboTime, m.PendingGarbage, speed))
+	} else if blockSize == 1 {
 		renderBuffer.WriteString(fmt.Sprintf(" Combo\n   %d\n\n Timer\n   %.0f\n\nPending\n   %d\n\n Speed\n  %s", combo, comboTime, m.PendingGarbage, speed))
 	} else if blockSize == 2 {
 		renderBuffer.WriteString(fmt.Sprintf("\n Combo\n\n   %d\n\n\n Timer\n\n   %.0f\n\n\nPending\n\n   %d\n\n\n Speed\n\n  %s", combo, comboTime, m.PendingGarbage, speed))
@@ -300,8 +359,10 @@ func renderPlayerGUI() {
 		renderBuffer.WriteString(fmt.Sprintf("\n\n\n\n\n    Combo\n\n      %d\n\n\n\n\n\n    Timer\n\n      %.0f\n\n\n\n\n\n   Pending\n\n      %d\n\n\n\n\n\n    Speed\n\n     %s", combo, comboTime, m.PendingGarbage, speed))
 	}
 
+	sideBuffer.Write(renderBuffer.Bytes())
+
 	side.Clear()
-	side.Write(renderBuffer.Bytes())
+	side.Write(sideBuffer.Bytes())
 
 	renderLock.Unlock()
 	m.Unlock()
@@ -316,7 +377,11 @@ func renderMultiplayerGUI() {
 	g.Lock()
 
 	if g.LocalPlayer == game.PlayerUnknown || len(g.Players) <= 1 {
-		buffer.Clear()
+		if g.Mode != game.ModeEndless {
+			renderModeGUI(g)
+		} else {
+			buffer.Clear()
+		}
 		g.Unlock()
 		return
 	}
@@ -350,15 +415,140 @@ func renderMultiplayerGUI() {
 		}
 	}
 
+	var target *mino.Matrix
+	if p, ok := g.Players[g.LocalPlayer]; ok {
+		if t, ok := g.Players[p.Target]; ok {
+			target = t.Matrix
+		}
+	}
+
 	g.Unlock()
 
 	renderLock.Lock()
+	targetMatrix = target
 	renderMatrixes(matrixes)
 	buffer.Clear()
 	buffer.Write(renderBuffer.Bytes())
 	renderLock.Unlock()
 }
 
+// renderModeGUI renders the progress of the mode played by the local player,
+// or its result once the mode has ended. The game must be locked.
+func renderModeGUI(g *game.Game) {
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		buffer.Clear()
+		return
+	}
+	m := p.Matrix
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n%s - %s\n\n", g.Mode, g.Mode.GoalString(g.ModeGoal)))
+
+	if r := g.Result; r != nil {
+		if r.Completed {
+			b.WriteString("Complete!\n\n")
+		} else if r.Mode.ScoreRanked() {
+			b.WriteString("Game over\n\n")
+		} else {
+			b.WriteString("Failed\n\n")
+		}
+
+		switch r.Mode {
+		case game.ModeUltra:
+			b.WriteString(fmt.Sprintf("Score   %d\nLines   %d\nPPS     %.2f\n", r.Score, r.Lines, r.PPS()))
+		case game.ModeMarathon:
+			b.WriteString(fmt.Sprintf("Score   %d\nLevel   %d\nLines   %d\nTime    %s\n", r.Score, r.Level, r.Lines, game.FormatDuration(r.Time)))
+		case game.ModeDig:
+			b.WriteString(fmt.Sprintf("Time    %s\nGarbage %d/%d\nPPS     %.2f\nPieces  %d\n", game.FormatDuration(r.Time), r.Garbage, r.Goal, r.PPS(), r.Pieces))
+		default:
+			b.WriteString(fmt.Sprintf("Time    %s\nPPS     %.2f\nPieces  %d\n", game.FormatDuration(r.Time), r.PPS(), r.Pieces))
+		}
+
+		if g.NewRecord {
+			b.WriteString("\nNew personal best!\n")
+		} else if (r.Mode == game.ModeSprint || r.Mode == game.ModeDig) && len(g.Records) > 0 {
+			b.WriteString(fmt.Sprintf("Best    %s\n", game.FormatDuration(g.Records[0].Time)))
+		}
+
+		if r.Mode.ScoreRanked() && len(g.Records) > 0 {
+			renderModeRecords(&b, g)
+		}
+
+		b.WriteString("\nPress R to play again")
+	} else {
+		elapsed := g.ModeTimeL()
+		garbage := g.DigClearedL()
+
+		m.Lock()
+		score, level, lines, pieces := m.Score, m.Level, m.LinesCleared, m.Pieces
+		m.Unlock()
+
+		var pps float64
+		if elapsed > 0 {
+			pps = float64(pieces) / elapsed.Seconds()
+		}
+
+		switch g.Mode {
+		case game.ModeUltra:
+			remaining := g.Mode.TimeLimit(g.ModeGoal) - elapsed
+			b.WriteString(fmt.Sprintf("Time    %s\nScore   %d\nLines   %d\nPPS     %.2f", game.FormatDuration(remaining), score, lines, pps))
+		case game.ModeMarathon:
+			b.WriteString(fmt.Sprintf("Level   %d/%d\nScore   %d\nLines   %d\nTime    %s", level, game.MarathonEndLevel, score, lines, game.FormatDuration(elapsed)))
+		case game.ModeDig:
+			b.WriteString(fmt.Sprintf("Time    %s\nGarbage %d/%d\nPieces  %d\nPPS     %.2f", game.FormatDuration(elapsed), garbage, g.ModeGoal, pieces, pps))
+		default:
+			b.WriteString(fmt.Sprintf("Time    %s\nLines   %d/%d\nPieces  %d\nPPS     %.2f", game.FormatDuration(elapsed), lines, g.ModeGoal, pieces, pps))
+		}
+	}
+
+	renderLock.Lock()
+	buffer.Clear()
+	buffer.Write([]byte(b.String()))
+	renderLock.Unlock()
+}
+
+// renderModeRecords renders the best scores of the mode and goal, listing as
+// many as fit beside the matrix. The result of the local player is marked.
+func renderModeRecords(b *strings.Builder, g *game.Game) {
+	rows := mainHeight - strings.Count(b.String(), "\n") - 4
+	if rows < 1 {
+		return
+	} else if rows > len(g.Records) {
+		rows = len(g.Records)
+	}
+
+	b.WriteString("\nBest scores\n")
+	for i, r := range g.Records[:rows] {
+		marker := ""
+		if r == g.Result {
+			marker = " <"
+		}
+
+		b.WriteString(fmt.Sprintf("%2d. %7d  %s%s\n", i+1, r.Score, r.Date.Local().Format("2006-01-02"), marker))
+	}
+}
+
+// handleModeTimer redraws the progress of the mode played by the local player
+// while its timer is running.
+func handleModeTimer() {
+	t := time.NewTicker(modeTimerRefresh)
+	for range t.C {
+		g := activeGame
+		if g == nil {
+			continue
+		}
+
+		g.Lock()
+		running := g.Mode != game.ModeEndless && g.Started && g.Result == nil
+		g.Unlock()
+
+		if running {
+			draw <- event.DrawMultiplayerMatrixes
+		}
+	}
+}
+
 func renderPlayerDetails(m *mino.Matrix, bs int) {
 	xMultiplier := 1
 	if bs == 2 {
@@ -367,6 +557,14 @@ func renderPlayerDetails(m *mino.Matrix, bs int) {
 		xMultiplier = 4
 	}
 
+	// Score is shown beneath the hold box
+	holdBoxWidth := holdWidth*xMultiplier + 1
+	score := strconv.Itoa(m.Score)
+	if len(score) >= holdBoxWidth {
+		score = strconv.Itoa(m.Score/1000) + "k"
+	}
+	renderBuffer.WriteString(fmt.Sprintf("%*s ", holdBoxWidth-1, score))
+
 	var buf string
 	if !showDetails {
 		buf = m.PlayerName
@@ -377,7 +575,21 @@ func renderPlayerDetails(m *mino.Matrix, bs int) {
 			buf = fmt.Sprintf("%d / %d  @  %d", m.GarbageSent, m.GarbageReceived, m.Speed)
 		}
 	}
-	if len(buf) > m.W*xMultiplier {
+	if m.BackToBack > 1 {
+		b2b := fmt.Sprintf("B2B %d", m.BackToBack-1)
+		if len(buf)+len(b2b)+1 > m.W*xMultiplier {
+			buf = b2b
+		} else {
+			buf += " " + b2b
+		}
+	}
+	if m == targetMatrix {
+		// Mark the opponent receiving garbage
+		if len(buf) > m.W*xMultiplier-4 {
+			buf = buf[:m.W*xMultiplier-4]
+		}
+		buf = "> " + buf + " <"
+	} else if len(buf) > m.W*xMultiplier {
 		buf = buf[:m.W*xMultiplier]
 	}
 
@@ -392,6 +604,107 @@ func renderPlayerDetails(m *mino.Matrix, bs int) {
 	}
 }
 
+// holdBlock returns the block of the held piece at the specified location
+// within the hold box, which is drawn alongside the top of the matrix.
+func holdBlock(m *mino.Matrix, x int, y int) mino.Block {
+	if m.Hold == nil {
+		return mino.BlockNone
+	}
+
+	w, h := m.Hold.Size()
+	offsetX := (holdWidth - w) / 2
+	offsetY := m.H - 1 - h
+	if !m.Hold.HasPoint(mino.Point{x - offsetX, y - offsetY}) {
+		return mino.BlockNone
+	}
+
+	if m.HoldUsed {
+		return m.Hold.Ghost
+	}
+	return m.Hold.Solid
+}
+
+func renderHoldPadding(xMultiplier int) {
+	for x := 0; x < holdWidth*xMultiplier+1; x++ {
+		renderBuffer.WriteRune(' ')
+	}
+}
+
+// renderHoldLevel renders the level beneath the hold box when gravity varies.
+func renderHoldLevel(m *mino.Matrix, xMultiplier int) {
+	if m.Rules.Gravity == mino.GravityStatic {
+		renderHoldPadding(xMultiplier)
+		return
+	}
+
+	holdBoxWidth := holdWidth*xMultiplier + 1
+	level := fmt.Sprintf("Lv %d", m.Level)
+	if len(level) >= holdBoxWidth {
+		level = strconv.Itoa(m.Level)
+	}
+	renderBuffer.WriteString(fmt.Sprintf("%*s ", holdBoxWidth-1, level))
+}
+
+// renderGarbageMeter draws the left border of a matrix at the specified row,
+// filled when the row is reached by pending garbage.
+func renderGarbageMeter(m *mino.Matrix, y int) {
+	if y >= m.PendingGarbage {
+		renderBuffer.Write(renderVLine)
+	} else if time.Until(m.PendingGarbageTime) > 0 {
+		renderBuffer.Write(renderMeterWaiting)
+	} else {
+		renderBuffer.Write(renderMeter)
+	}
+}
+
+// renderHalfBlocks renders two vertically stacked blocks as a single character.
+func renderHalfBlocks(upper mino.Block, lower mino.Block) {
+	if lower == mino.BlockNone && upper == mino.BlockNone {
+		renderBuffer.WriteRune(' ')
+		return
+	} else if lower == mino.BlockNone {
+		renderBuffer.WriteRune('[')
+		renderBuffer.Write(mino.Colors[upper])
+		renderBuffer.WriteRune(']')
+		renderBuffer.WriteRune('▀')
+		renderBuffer.Write([]byte("[-:-]"))
+		return
+	} else if upper == mino.BlockNone {
+		renderBuffer.WriteRune('[')
+		renderBuffer.Write(mino.Colors[lower])
+		renderBuffer.WriteRune(']')
+		renderBuffer.WriteRune('▄')
+		renderBuffer.Write([]byte("[-:-]"))
+		return
+	}
+
+	renderBuffer.WriteRune('[')
+	renderBuffer.Write(mino.Colors[lower])
+	renderBuffer.WriteRune(':')
+	renderBuffer.Write(mino.Colors[upper])
+	renderBuffer.WriteRune(']')
+	renderBuffer.WriteRune('▄')
+	renderBuffer.Write([]byte("[-:-]"))
+}
+
+// renderBlock renders a block as width characters.
+func renderBlock(b mino.Block, width int) {
+	if b == mino.BlockNone {
+		for i := 0; i < width; i++ {
+			renderBuffer.WriteRune(' ')
+		}
+		return
+	}
+
+	renderBuffer.WriteRune('[')
+	renderBuffer.Write(mino.Colors[b])
+	renderBuffer.WriteRune(']')
+	for i := 0; i < width; i++ {
+		renderBuffer.WriteRune('█')
+	}
+	renderBuffer.Write([]byte("[-]"))
+}
+
 func renderMatrixes(mx []*mino.Matrix) {
 	renderBuffer.Reset()
 	if len(mx) == 0 {
@@ -406,10 +719,12 @@ func renderMatrixes(mx []*mino.Matrix) {
 	var nextPieceWidth = 0
 	if mt == mino.MatrixPreview {
 		renderBuffer.WriteRune('\n')
-		if mx[0].Bag != nil {
-			p := mx[0].Bag.Next()
-			if p != nil {
-				nextPieceWidth, _ = p.Size()
+		for x := mx[0].W - 1; x >= 0 && nextPieceWidth == 0; x-- {
+			for y := 0; y < mx[0].H; y++ {
+				if mx[0].Block(x, y) != mino.BlockNone {
+					nextPieceWidth = x + 1
+					break
+				}
 			}
 		}
 
@@ -447,6 +762,8 @@ func renderMatrixes(mx []*mino.Matrix) {
 				renderBuffer.WriteString(div)
 			}
 
+			renderHoldPadding(xMultiplier)
+
 			renderBuffer.Write(renderULCorner)
 			for x := 0; x < mx[i].W*xMultiplier; x++ {
 				renderBuffer.Write(renderHLine)
@@ -464,38 +781,18 @@ func renderMatrixes(mx []*mino.Matrix) {
 				}
 
 				if m.Type == mino.MatrixStandard {
-					renderBuffer.Write(renderVLine)
+					for x := 0; x < holdWidth; x++ {
+						renderHalfBlocks(holdBlock(m, x, y), holdBlock(m, x, y-1))
+					}
+					renderBuffer.WriteRune(' ')
+
+					renderGarbageMeter(m, y-1)
 				} else if m.Type == mino.MatrixPreview {
 					renderBuffer.WriteRune(' ')
 				}
 
 				for x := 0; x < m.W; x++ {
-					if m.Block(x, y-1) == mino.BlockNone && m.Block(x, y) == mino.BlockNone {
-						renderBuffer.WriteRune(' ')
-						continue
-					} else if m.Block(x, y-1) == mino.BlockNone {
-						renderBuffer.WriteRune('[')
-						renderBuffer.Write(mino.Colors[m.Block(x, y)])
-						renderBuffer.WriteRune(']')
-						renderBuffer.WriteRune('▀')
-						renderBuffer.Write([]byte("[-:-]"))
-						continue
-					} else if m.Block(x, y) == mino.BlockNone {
-						renderBuffer.WriteRune('[')
-						renderBuffer.Write(mino.Colors[m.Block(x, y-1)])
-						renderBuffer.WriteRune(']')
-						renderBuffer.WriteRune('▄')
-						renderBuffer.Write([]byte("[-:-]"))
-						continue
-					}
-
-					renderBuffer.WriteRune('[')
-					renderBuffer.Write(mino.Colors[m.Block(x, y-1)])
-					renderBuffer.WriteRune(':')
-					renderBuffer.Write(mino.Colors[m.Block(x, y)])
-					renderBuffer.WriteRune(']')
-					renderBuffer.WriteRune('▄')
-					renderBuffer.Write([]byte("[-:-]"))
+					renderHalfBlocks(m.Block(x, y), m.Block(x, y-1))
 				}
 
 				if m.Type == mino.MatrixStandard {
@@ -515,7 +812,12 @@ func renderMatrixes(mx []*mino.Matrix) {
 				}
 
 				if m.Type == mino.MatrixStandard {
-					renderBuffer.Write(renderVLine)
+					for x := 0; x < holdWidth; x++ {
+						renderBlock(holdBlock(m, x, y), xMultiplier)
+					}
+					renderBuffer.WriteRune(' ')
+
+					renderGarbageMeter(m, y)
 				} else if m.Type == mino.MatrixPreview {
 					if nextPieceWidth < 4 {
 						renderBuffer.WriteRune(' ')
@@ -523,18 +825,7 @@ func renderMatrixes(mx []*mino.Matrix) {
 				}
 
 				for x := 0; x < m.W; x++ {
-					if m.Block(x, y) == mino.BlockNone {
-						renderBuffer.WriteRune(' ')
-						renderBuffer.WriteRune(' ')
-						continue
-					}
-
-					renderBuffer.WriteRune('[')
-					renderBuffer.Write(mino.Colors[m.Block(x, y)])
-					renderBuffer.WriteRune(']')
-					renderBuffer.WriteRune('█')
-					renderBuffer.WriteRune('█')
-					renderBuffer.Write([]byte("[-]"))
+					renderBlock(m.Block(x, y), xMultiplier)
 				}
 
 				if m.Type == mino.MatrixStandard {
@@ -555,7 +846,12 @@ func renderMatrixes(mx []*mino.Matrix) {
 					}
 
 					if m.Type == mino.MatrixStandard {
-						renderBuffer.Write(renderVLine)
+						for x := 0; x < holdWidth; x++ {
+							renderBlock(holdBlock(m, x, y), xMultiplier)
+						}
+						renderBuffer.WriteRune(' ')
+
+						renderGarbageMeter(m, y)
 					} else if m.Type == mino.MatrixPreview {
 						if nextPieceWidth < 4 {
 							renderBuffer.WriteRune(' ')
@@ -563,22 +859,7 @@ func renderMatrixes(mx []*mino.Matrix) {
 					}
 
 					for x := 0; x < m.W; x++ {
-						if m.Block(x, y) == mino.BlockNone {
-							renderBuffer.WriteRune(' ')
-							renderBuffer.WriteRune(' ')
-							renderBuffer.WriteRune(' ')
-							renderBuffer.WriteRune(' ')
-							continue
-						}
-
-						renderBuffer.WriteRune('[')
-						renderBuffer.Write(mino.Colors[m.Block(x, y)])
-						renderBuffer.WriteRune(']')
-						renderBuffer.WriteRune('█')
-						renderBuffer.WriteRune('█')
-						renderBuffer.WriteRune('█')
-						renderBuffer.WriteRune('█')
-						renderBuffer.Write([]byte("[-]"))
+						renderBlock(m.Block(x, y), xMultiplier)
 					}
 
 					if m.Type == mino.MatrixStandard {
@@ -599,6 +880,8 @@ func renderMatrixes(mx []*mino.Matrix) {
 				renderBuffer.WriteString(div)
 			}
 
+			renderHoldLevel(mx[i], xMultiplier)
+
 			renderBuffer.Write(renderLLCorner)
 			for x := 0; x < mx[i].W*xMultiplier; x++ {
 				renderBuffer.Write(renderHLine)
@@ -232,9 +232,9 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	gameListGrid.AddItem(gameListHelp, 4, 1, 1, 1, 0, 0, true)
 
 	buttonNewGameCancel = cview.NewButton("Cancel")
-	buttonNewGameCancel.SetSelectedFunc(selectTitleFunc(3))
+	buttonNewGameCancel.SetSelectedFunc(selectTitleFunc(newGameOptionsStart + len(newGameOptions)))
 	buttonNewGameStart = cview.NewButton("Start")
-	buttonNewGameStart.SetSelectedFunc(selectTitleFunc(4))
+	buttonNewGameStart.SetSelectedFunc(selectTitleFunc(newGameOptionsStart + len(newGameOptions) + 1))
 
 	styleButton(buttonNewGameCancel)
 	styleButton(buttonNewGameStart)
@@ -264,12 +264,19 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	styleInputField(newGameMaxPlayersInput)
 	styleInputField(newGameSpeedLimitInput)
 
+	for i, o := range newGameOptions {
+		o.button = cview.NewButton(o.values[0])
+		o.button.SetSelectedFunc(selectTitleFunc(newGameOptionsStart + i))
+		styleButton(o.button)
+	}
+
 	resetNewGameInputs()
 
 	newGameNameLabel := cview.NewTextView()
 	newGameNameLabel.SetText("Name")
 
 	newGameNameGrid := cview.NewGrid()
+	newGameNameGrid.SetColumns(19, -1)
 	newGameNameGrid.AddItem(newGameNameLabel, 0, 0, 1, 1, 0, 0, false)
 	newGameNameGrid.AddItem(newGameNameInput, 0, 1, 1, 1, 0, 0, false)
 
@@ -277,6 +284,7 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	newGameMaxPlayersLabel.SetText("Player Limit")
 
 	newGameMaxPlayersGrid := cview.NewGrid()
+	newGameMaxPlayersGrid.SetColumns(19, -1)
 	newGameMaxPlayersGrid.AddItem(newGameMaxPlayersLabel, 0, 0, 1, 1, 0, 0, false)
 	newGameMaxPlayersGrid.AddItem(newGameMaxPlayersInput, 0, 1, 1, 1, 0, 0, false)
 
@@ -284,6 +292,7 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	newGameSpeedLimitLabel.SetText("Speed Limit")
 
 	newGameSpeedLimitGrid := cview.NewGrid()
+	newGameSpeedLimitGrid.SetColumns(19, -1)
 	newGameSpeedLimitGrid.AddItem(newGameSpeedLimitLabel, 0, 0, 1, 1, 0, 0, false)
 	newGameSpeedLimitGrid.AddItem(newGameSpeedLimitInput, 0, 1, 1, 1, 0, 0, false)
 
@@ -297,24 +306,105 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	newGameHelp.SetTextAlign(cview.AlignCenter)
 	newGameHelp.SetWrap(false)
 	newGameHelp.SetWordWrap(false)
-	newGameHelp.SetText("\nLimits set to zero are disabled\nPrevious: Shift+Tab - Next: Tab")
+	newGameHelp.SetText("Limits set to zero are disabled\nPrevious: Shift+Tab - Next: Tab")
+
+	// Rows are kept compact to fit standard 80x24 terminals
+	newGameRows := []int{5, 2, 1, 1, 1}
+	for range newGameOptions {
+		newGameRows = append(newGameRows, 1)
+	}
+	newGameRows = append(newGameRows, 1, -1)
 
 	newGameGrid = cview.NewGrid()
-	newGameGrid.SetRows(5, 2, 1, 1, 1, 1, 1, 1, 1, -1, 3)
+	newGameGrid.SetRows(newGameRows...)
 	newGameGrid.SetColumns(-1, 34, -1)
-	newGameGrid.AddItem(titleL, 0, 0, 11, 1, 0, 0, false)
+	newGameGrid.AddItem(titleL, 0, 0, len(newGameRows), 1, 0, 0, false)
 	newGameGrid.AddItem(titleNameGrid, 0, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(titleR, 0, 2, 11, 1, 0, 0, false)
+	newGameGrid.AddItem(titleR, 0, 2, len(newGameRows), 1, 0, 0, false)
 	newGameGrid.AddItem(newGameHeader, 1, 1, 1, 1, 0, 0, false)
 	newGameGrid.AddItem(newGameNameGrid, 2, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(pad, 3, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(newGameMaxPlayersGrid, 4, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(pad, 5, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(newGameSpeedLimitGrid, 6, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(pad, 7, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(newGameSubmitGrid, 8, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(pad, 9, 1, 1, 1, 0, 0, false)
-	newGameGrid.AddItem(newGameHelp, 10, 1, 1, 1, 0, 0, false)
+	newGameGrid.AddItem(newGameMaxPlayersGrid, 3, 1, 1, 1, 0, 0, false)
+	newGameGrid.AddItem(newGameSpeedLimitGrid, 4, 1, 1, 1, 0, 0, false)
+	row := 5
+	for _, o := range newGameOptions {
+		optionLabel := cview.NewTextView()
+		optionLabel.SetText(o.label)
+
+		optionGrid := cview.NewGrid()
+		optionGrid.SetColumns(19, -1)
+		optionGrid.AddItem(optionLabel, 0, 0, 1, 1, 0, 0, false)
+		optionGrid.AddItem(o.button, 0, 1, 1, 1, 0, 0, false)
+
+		newGameGrid.AddItem(optionGrid, row, 1, 1, 1, 0, 0, false)
+		row++
+	}
+	newGameGrid.AddItem(newGameSubmitGrid, row, 1, 1, 1, 0, 0, false)
+	newGameGrid.AddItem(newGameHelp, row+1, 1, 1, 1, 0, 0, false)
+
+	buttonPracticeCancel = cview.NewButton("Cancel")
+	buttonPracticeCancel.SetSelectedFunc(selectTitleFunc(len(practiceOptions)))
+	buttonPracticeStart = cview.NewButton("Start")
+	buttonPracticeStart.SetSelectedFunc(selectTitleFunc(len(practiceOptions) + 1))
+
+	styleButton(buttonPracticeCancel)
+	styleButton(buttonPracticeStart)
+
+	practiceSubmitGrid := cview.NewGrid()
+	practiceSubmitGrid.SetColumns(-1, 10, 1, 10, -1)
+	practiceSubmitGrid.AddItem(pad, 0, 0, 1, 1, 0, 0, false)
+	practiceSubmitGrid.AddItem(buttonPracticeCancel, 0, 1, 1, 1, 0, 0, false)
+	practiceSubmitGrid.AddItem(pad, 0, 2, 1, 1, 0, 0, false)
+	practiceSubmitGrid.AddItem(buttonPracticeStart, 0, 3, 1, 1, 0, 0, false)
+	practiceSubmitGrid.AddItem(pad, 0, 4, 1, 1, 0, 0, false)
+
+	for i, o := range practiceOptions {
+		o.button = cview.NewButton(o.values[0])
+		o.button.SetSelectedFunc(selectTitleFunc(i))
+		styleButton(o.button)
+	}
+
+	practiceHeader := cview.NewTextView()
+	practiceHeader.SetTextAlign(cview.AlignCenter)
+	practiceHeader.SetWrap(false)
+	practiceHeader.SetWordWrap(false)
+	practiceHeader.SetText("Practice")
+
+	practiceHelp := cview.NewTextView()
+	practiceHelp.SetTextAlign(cview.AlignCenter)
+	practiceHelp.SetWrap(false)
+	practiceHelp.SetWordWrap(false)
+	practiceHelp.SetText("\nPress R while playing to restart\nPrevious: Shift+Tab - Next: Tab")
+
+	practiceRows := []int{5, 2}
+	for range practiceOptions {
+		practiceRows = append(practiceRows, 1)
+	}
+	practiceRows = append(practiceRows, 1, 1, -1, 3)
+
+	practiceGrid = cview.NewGrid()
+	practiceGrid.SetRows(practiceRows...)
+	practiceGrid.SetColumns(-1, 34, -1)
+	practiceGrid.AddItem(titleL, 0, 0, len(practiceRows), 1, 0, 0, false)
+	practiceGrid.AddItem(titleNameGrid, 0, 1, 1, 1, 0, 0, false)
+	practiceGrid.AddItem(titleR, 0, 2, len(practiceRows), 1, 0, 0, false)
+	practiceGrid.AddItem(practiceHeader, 1, 1, 1, 1, 0, 0, false)
+	row = 2
+	for _, o := range practiceOptions {
+		optionLabel := cview.NewTextView()
+		optionLabel.SetText(o.label)
+
+		optionGrid := cview.NewGrid()
+		optionGrid.SetColumns(19, -1)
+		optionGrid.AddItem(optionLabel, 0, 0, 1, 1, 0, 0, false)
+		optionGrid.AddItem(o.button, 0, 1, 1, 1, 0, 0, false)
+
+		practiceGrid.AddItem(optionGrid, row, 1, 1, 1, 0, 0, false)
+		row++
+	}
+	practiceGrid.AddItem(pad, row, 1, 1, 1, 0, 0, false)
+	practiceGrid.AddItem(practiceSubmitGrid, row+1, 1, 1, 1, 0, 0, false)
+	practiceGrid.AddItem(pad, row+2, 1, 1, 1, 0, 0, false)
+	practiceGrid.AddItem(practiceHelp, row+3, 1, 1, 1, 0, 0, false)
 
 	playerSettingsTitle := cview.NewTextView()
 	playerSettingsTitle.SetTextAlign(cview.AlignCenter)
@@ -393,6 +483,8 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	labelKeybindRotateCCW.SetText("Rotate CCW")
 	labelKeybindRotateCW := cview.NewTextView()
 	labelKeybindRotateCW.SetText("Rotate CW")
+	labelKeybindRotate180 := cview.NewTextView()
+	labelKeybindRotate180.SetText("Rotate 180")
 	labelKeybindMoveLeft := cview.NewTextView()
 	labelKeybindMoveLeft.SetText("Move Left")
 	labelKeybindMoveRight := cview.NewTextView()
@@ -401,31 +493,54 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	labelKeybindSoftDrop.SetText("Soft Drop")
 	labelKeybindHardDrop := cview.NewTextView()
 	labelKeybindHardDrop.SetText("Hard Drop")
+	labelKeybindHold := cview.NewTextView()
+	labelKeybindHold.SetText("Hold")
+	labelKeybindTarget := cview.NewTextView()
+	labelKeybindTarget.SetText("Change Target")
+	labelKeybindFumen := cview.NewTextView()
+	labelKeybindFumen.SetText("Export Fumen")
+	labelKeybindRetry := cview.NewTextView()
+	labelKeybindRetry.SetText("Retry")
 
 	buttonKeybindRotateCCW = cview.NewButton("Set")
 	buttonKeybindRotateCCW.SetSelectedFunc(selectTitleFunc(1))
 	buttonKeybindRotateCW = cview.NewButton("Set")
 	buttonKeybindRotateCW.SetSelectedFunc(selectTitleFunc(2))
+	buttonKeybindRotate180 = cview.NewButton("Set")
+	buttonKeybindRotate180.SetSelectedFunc(selectTitleFunc(3))
 	buttonKeybindMoveLeft = cview.NewButton("Set")
-	buttonKeybindMoveLeft.SetSelectedFunc(selectTitleFunc(3))
+	buttonKeybindMoveLeft.SetSelectedFunc(selectTitleFunc(4))
 	buttonKeybindMoveRight = cview.NewButton("Set")
-	buttonKeybindMoveRight.SetSelectedFunc(selectTitleFunc(4))
+	buttonKeybindMoveRight.SetSelectedFunc(selectTitleFunc(5))
 	buttonKeybindSoftDrop = cview.NewButton("Set")
-	buttonKeybindSoftDrop.SetSelectedFunc(selectTitleFunc(5))
+	buttonKeybindSoftDrop.SetSelectedFunc(selectTitleFunc(6))
 	buttonKeybindHardDrop = cview.NewButton("Set")
-	buttonKeybindHardDrop.SetSelectedFunc(selectTitleFunc(6))
+	buttonKeybindHardDrop.SetSelectedFunc(selectTitleFunc(7))
+	buttonKeybindHold = cview.NewButton("Set")
+	buttonKeybindHold.SetSelectedFunc(selectTitleFunc(8))
+	buttonKeybindTarget = cview.NewButton("Set")
+	buttonKeybindTarget.SetSelectedFunc(selectTitleFunc(9))
+	buttonKeybindFumen = cview.NewButton("Set")
+	buttonKeybindFumen.SetSelectedFunc(selectTitleFunc(10))
+	buttonKeybindRetry = cview.NewButton("Set")
+	buttonKeybindRetry.SetSelectedFunc(selectTitleFunc(11))
 
 	buttonKeybindCancel = cview.NewButton("Cancel")
-	buttonKeybindCancel.SetSelectedFunc(selectTitleFunc(7))
+	buttonKeybindCancel.SetSelectedFunc(selectTitleFunc(12))
 	buttonKeybindSave = cview.NewButton("Save")
-	buttonKeybindSave.SetSelectedFunc(selectTitleFunc(8))
+	buttonKeybindSave.SetSelectedFunc(selectTitleFunc(13))
 
 	styleButton(buttonKeybindRotateCCW)
 	styleButton(buttonKeybindRotateCW)
+	styleButton(buttonKeybindRotate180)
 	styleButton(buttonKeybindMoveLeft)
 	styleButton(buttonKeybindMoveRight)
 	styleButton(buttonKeybindSoftDrop)
 	styleButton(buttonKeybindHardDrop)
+	styleButton(buttonKeybindHold)
+	styleButton(buttonKeybindTarget)
+	styleButton(buttonKeybindFumen)
+	styleButton(buttonKeybindRetry)
 	styleButton(buttonKeybindCancel)
 	styleButton(buttonKeybindSave)
 
@@ -439,6 +554,11 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	rotateCWGrid.AddItem(labelKeybindRotateCW, 0, 0, 1, 1, 0, 0, false)
 	rotateCWGrid.AddItem(buttonKeybindRotateCW, 0, 1, 1, 1, 0, 0, false)
 
+	rotate180Grid := cview.NewGrid()
+	rotate180Grid.SetColumns(27, -1)
+	rotate180Grid.AddItem(labelKeybindRotate180, 0, 0, 1, 1, 0, 0, false)
+	rotate180Grid.AddItem(buttonKeybindRotate180, 0, 1, 1, 1, 0, 0, false)
+
 	moveLeftGrid := cview.NewGrid()
 	moveLeftGrid.SetColumns(27, -1)
 	moveLeftGrid.AddItem(labelKeybindMoveLeft, 0, 0, 1, 1, 0, 0, false)
@@ -459,6 +579,26 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	hardDropGrid.AddItem(labelKeybindHardDrop, 0, 0, 1, 1, 0, 0, false)
 	hardDropGrid.AddItem(buttonKeybindHardDrop, 0, 1, 1, 1, 0, 0, false)
 
+	holdGrid := cview.NewGrid()
+	holdGrid.SetColumns(27, -1)
+	holdGrid.AddItem(labelKeybindHold, 0, 0, 1, 1, 0, 0, false)
+	holdGrid.AddItem(buttonKeybindHold, 0, 1, 1, 1, 0, 0, false)
+
+	targetGrid := cview.NewGrid()
+	targetGrid.SetColumns(27, -1)
+	targetGrid.AddItem(labelKeybindTarget, 0, 0, 1, 1, 0, 0, false)
+	targetGrid.AddItem(buttonKeybindTarget, 0, 1, 1, 1, 0, 0, false)
+
+	fumenGrid := cview.NewGrid()
+	fumenGrid.SetColumns(27, -1)
+	fumenGrid.AddItem(labelKeybindFumen, 0, 0, 1, 1, 0, 0, false)
+	fumenGrid.AddItem(buttonKeybindFumen, 0, 1, 1, 1, 0, 0, false)
+
+	retryGrid := cview.NewGrid()
+	retryGrid.SetColumns(27, -1)
+	retryGrid.AddItem(labelKeybindRetry, 0, 0, 1, 1, 0, 0, false)
+	retryGrid.AddItem(buttonKeybindRetry, 0, 1, 1, 1, 0, 0, false)
+
 	gameSettingsSubmitGrid := cview.NewGrid()
 	gameSettingsSubmitGrid.SetColumns(-1, 10, 1, 10, -1)
 	gameSettingsSubmitGrid.AddItem(pad, 0, 0, 1, 1, 0, 0, false)
@@ -467,12 +607,6 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	gameSettingsSubmitGrid.AddItem(buttonKeybindSave, 0, 3, 1, 1, 0, 0, false)
 	gameSettingsSubmitGrid.AddItem(pad, 0, 4, 1, 1, 0, 0, false)
 
-	gameSettingsOptionsTitle := cview.NewTextView()
-	gameSettingsOptionsTitle.SetTextAlign(cview.AlignCenter)
-	gameSettingsOptionsTitle.SetWrap(false)
-	gameSettingsOptionsTitle.SetWordWrap(false)
-	gameSettingsOptionsTitle.SetText("Options")
-
 	gameSettingsKeybindsTitle := cview.NewTextView()
 	gameSettingsKeybindsTitle.SetTextAlign(cview.AlignCenter)
 	gameSettingsKeybindsTitle.SetWrap(false)
@@ -483,31 +617,33 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	gameSettingsHelp.SetTextAlign(cview.AlignCenter)
 	gameSettingsHelp.SetWrap(false)
 	gameSettingsHelp.SetWordWrap(false)
-	gameSettingsHelp.SetText("\nPrevious: Shift+Tab - Next: Tab")
+	gameSettingsHelp.SetText("Previous: Shift+Tab - Next: Tab")
 
+	// Rows are kept compact to fit standard 80x24 terminals
 	gameSettingsGrid = cview.NewGrid()
-	gameSettingsGrid.SetRows(5, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1)
+	gameSettingsGrid.SetRows(5, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1)
 	gameSettingsGrid.SetColumns(-1, 34, -1)
-	gameSettingsGrid.AddItem(titleL, 0, 0, 18, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(titleL, 0, 0, 19, 1, 0, 0, false)
 	gameSettingsGrid.AddItem(titleNameGrid, 0, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(titleR, 0, 2, 18, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(titleR, 0, 2, 19, 1, 0, 0, false)
 	gameSettingsGrid.AddItem(gameSettingsTitle, 1, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(pad, 2, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(gameSettingsOptionsTitle, 3, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(ghostPieceGrid, 4, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(ghostPieceGrid, 5, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(pad, 6, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(gameSettingsKeybindsTitle, 7, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(pad, 8, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(rotateCCWGrid, 9, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(rotateCWGrid, 10, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(moveLeftGrid, 11, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(moveRightGrid, 12, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(softDropGrid, 13, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(hardDropGrid, 14, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(pad, 15, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(gameSettingsSubmitGrid, 16, 1, 1, 1, 0, 0, false)
-	gameSettingsGrid.AddItem(gameSettingsHelp, 17, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(ghostPieceGrid, 2, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(pad, 3, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(gameSettingsKeybindsTitle, 4, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(rotateCCWGrid, 5, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(rotateCWGrid, 6, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(rotate180Grid, 7, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(moveLeftGrid, 8, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(moveRightGrid, 9, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(softDropGrid, 10, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(hardDropGrid, 11, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(holdGrid, 12, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(targetGrid, 13, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(fumenGrid, 14, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(retryGrid, 15, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(pad, 16, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(gameSettingsSubmitGrid, 17, 1, 1, 1, 0, 0, false)
+	gameSettingsGrid.AddItem(gameSettingsHelp, 18, 1, 1, 1, 0, 0, false)
 
 	titleContainerGrid = cview.NewGrid()
 	titleContainerGrid.SetColumns(-1, 80, -1)
@@ -536,6 +672,15 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	newGameContainerGrid.AddItem(pad, 1, 2, 1, 1, 0, 0, false)
 	newGameContainerGrid.AddItem(pad, 0, 0, 1, 3, 0, 0, false)
 
+	practiceContainerGrid = cview.NewGrid()
+	practiceContainerGrid.SetColumns(-1, 80, -1)
+	practiceContainerGrid.SetRows(-1, 24, -1)
+	practiceContainerGrid.AddItem(pad, 0, 0, 1, 3, 0, 0, false)
+	practiceContainerGrid.AddItem(pad, 1, 0, 1, 1, 0, 0, false)
+	practiceContainerGrid.AddItem(practiceGrid, 1, 1, 1, 1, 0, 0, false)
+	practiceContainerGrid.AddItem(pad, 1, 2, 1, 1, 0, 0, false)
+	practiceContainerGrid.AddItem(pad, 0, 0, 1, 3, 0, 0, false)
+
 	playerSettingsContainerGrid = cview.NewGrid()
 	playerSettingsContainerGrid.SetColumns(-1, 80, -1)
 	playerSettingsContainerGrid.SetRows(-1, 24, -1)
@@ -567,6 +712,7 @@ func initGUI(skipTitle bool) (*cview.Application, error) {
 	}
 
 	go handleDraw()
+	go handleModeTimer()
 
 	return app, nil
 }
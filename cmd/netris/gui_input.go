@@ -6,6 +6,8 @@ import (
 	"os"
 	"runtime/pprof"
 	"strings"
+	"sync"
+	"time"
 
 	"code.rocket9labs.com/tslocum/netris/pkg/event"
 	"code.rocket9labs.com/tslocum/netris/pkg/game"
@@ -24,21 +26,181 @@ type Keybinding struct {
 var actionHandlers = map[event.GameAction]func(*tcell.EventKey) *tcell.EventKey{
 	event.ActionRotateCCW: rotateCCW,
 	event.ActionRotateCW:  rotateCW,
+	event.ActionRotate180: rotate180,
 	event.ActionMoveLeft:  moveLeft,
 	event.ActionMoveRight: moveRight,
 	event.ActionSoftDrop:  softDrop,
 	event.ActionHardDrop:  hardDrop,
+	event.ActionHold:      hold,
+	event.ActionTarget:    target,
+	event.ActionFumen:     exportFumen,
+	event.ActionRetry:     retry,
 }
 
 var inputConfig = cbind.NewConfiguration()
 
+// Auto shift defaults, in milliseconds
+const (
+	DefaultDAS = 167 // Delayed auto shift
+	DefaultARR = 33  // Auto repeat rate
+	DefaultSDF = 20  // Soft drop factor
+	MaxSDF     = 40
+)
+
+const (
+	// Presses of a key this close together are repeats sent by the terminal
+	// while the key is held
+	keyRepeatWindow = 100 * time.Millisecond
+
+	// Presses of a key this close together continue charging delayed auto
+	// shift, as terminals wait before repeating a held key
+	keyRepeatDelay = time.Second
+
+	// Shortest time between automatic moves
+	autoShiftPoll = 10 * time.Millisecond
+)
+
+// shiftKey is a movement key which may be held to repeat its action.
+// Terminals only report key presses, so a key is considered held while the
+// terminal repeats it. Automatic moves can not begin before the terminal's key
+// repeat delay has passed, even when DAS is shorter.
+type shiftKey struct {
+	action   event.GameAction
+	pressed  time.Time     // Time of the initial press
+	last     time.Time     // Time of the last press or repeat
+	interval time.Duration // Time between repeats
+	held     bool
+}
+
+var (
+	heldKey     *shiftKey
+	heldKeyLock = new(sync.Mutex)
+)
+
+// released returns whether the terminal has stopped repeating the key.
+func (k *shiftKey) released(now time.Time) bool {
+	release := 2 * k.interval
+	if release < 5*autoShiftPoll {
+		release = 5 * autoShiftPoll
+	} else if release > keyRepeatWindow {
+		release = keyRepeatWindow
+	}
+
+	return now.Sub(k.last) >= release
+}
+
+// press records a press of the key and returns whether it was repeated by
+// the terminal, and whether the key is now held for the first time.
+func (k *shiftKey) press(now time.Time) (repeat bool, held bool) {
+	repeat = !k.last.IsZero() && now.Sub(k.last) < keyRepeatWindow
+	if repeat {
+		k.interval = now.Sub(k.last)
+		held = !k.held
+		k.held = true
+	}
+	k.last = now
+
+	return repeat, held
+}
+
+// pressShiftKey applies a movement action when its key is pressed, and
+// repeats the action while the key is held.
+func pressShiftKey(a event.GameAction) {
+	now := time.Now()
+
+	heldKeyLock.Lock()
+	k := heldKey
+	if k == nil || k.action != a || now.Sub(k.last) >= keyRepeatDelay {
+		k = &shiftKey{action: a, pressed: now}
+		heldKey = k
+	}
+	repeat, held := k.press(now)
+	heldKeyLock.Unlock()
+
+	if !repeat {
+		activeGame.ProcessAction(a)
+	}
+	if held {
+		go autoShift(k)
+	}
+}
+
+// autoShift repeats the action of a held key until it is released.
+func autoShift(k *shiftKey) {
+	if k.action != event.ActionSoftDrop {
+		heldKeyLock.Lock()
+		charged := k.pressed.Add(time.Duration(config.DAS) * time.Millisecond)
+		heldKeyLock.Unlock()
+
+		time.Sleep(time.Until(charged))
+	}
+
+	for {
+		heldKeyLock.Lock()
+		if heldKey != k || k.released(time.Now()) {
+			if heldKey == k {
+				heldKey = nil
+			}
+			heldKeyLock.Unlock()
+			return
+		}
+		heldKeyLock.Unlock()
+
+		time.Sleep(autoShiftStep(k.action))
+	}
+}
+
+// autoShiftStep applies an automatic move and returns the time to wait before
+// the next move. Moves are processed as any other action would be.
+func autoShiftStep(a event.GameAction) time.Duration {
+	g := activeGame
+	if g == nil {
+		return autoShiftPoll
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		return autoShiftPoll
+	}
+
+	var wait time.Duration
+	switch a {
+	case event.ActionSoftDrop:
+		g.ProcessActionL(a)
+
+		wait = p.Matrix.FallTime(g.FallTime) / time.Duration(config.SDF)
+	default:
+		moves := 1
+		if config.ARR == 0 {
+			// Move to the wall instantly
+			moves = p.Matrix.W
+		}
+
+		for i := 0; i < moves; i++ {
+			g.ProcessActionL(a)
+		}
+
+		wait = time.Duration(config.ARR) * time.Millisecond
+	}
+
+	if wait < autoShiftPoll {
+		wait = autoShiftPoll
+	}
+	return wait
+}
+
 var draftKeybindings []*Keybinding
 
 func setKeyBinds() error {
-	if len(config.Input) == 0 {
-		setDefaultKeyBinds()
+	if config.Input == nil {
+		config.Input = make(map[event.GameAction][]string)
 	}
 
+	addDefaultKeyBinds(config.Input)
+
 	for a, keys := range config.Input {
 		a = event.GameAction(strings.ToLower(string(a)))
 		handler := actionHandlers[a]
@@ -63,14 +225,44 @@ func setKeyBinds() error {
 	return nil
 }
 
-func setDefaultKeyBinds() {
-	config.Input = map[event.GameAction][]string{
+// addDefaultKeyBinds binds actions missing from the input configuration to
+// their default keys. Default keys already bound to another action are skipped.
+func addDefaultKeyBinds(input map[event.GameAction][]string) {
+	bound := make(map[string]bool)
+	for _, keys := range input {
+		for _, k := range keys {
+			bound[k] = true
+		}
+	}
+
+	for a, keys := range defaultKeyBinds() {
+		if _, ok := input[a]; ok {
+			continue
+		}
+
+		var free []string
+		for _, k := range keys {
+			if !bound[k] {
+				free = append(free, k)
+			}
+		}
+		input[a] = free
+	}
+}
+
+func defaultKeyBinds() map[event.GameAction][]string {
+	return map[event.GameAction][]string{
 		event.ActionRotateCCW: {"z", "Z"},
 		event.ActionRotateCW:  {"x", "X"},
+		event.ActionRotate180: {"a", "A"},
 		event.ActionMoveLeft:  {"Left", "h", "H"},
 		event.ActionMoveRight: {"Right", "l", "L"},
 		event.ActionSoftDrop:  {"Down", "j", "J"},
 		event.ActionHardDrop:  {"Up", "k", "K"},
+		event.ActionHold:      {"c", "C"},
+		event.ActionTarget:    {"t", "T"},
+		event.ActionFumen:     {"f", "F"},
+		event.ActionRetry:     {"r", "R"},
 	}
 }
 
@@ -124,13 +316,23 @@ func handleKeypress(ev *tcell.EventKey) *tcell.EventKey {
 		case 2:
 			action = event.ActionRotateCW
 		case 3:
-			action = event.ActionMoveLeft
+			action = event.ActionRotate180
 		case 4:
-			action = event.ActionMoveRight
+			action = event.ActionMoveLeft
 		case 5:
-			action = event.ActionSoftDrop
+			action = event.ActionMoveRight
 		case 6:
+			action = event.ActionSoftDrop
+		case 7:
 			action = event.ActionHardDrop
+		case 8:
+			action = event.ActionHold
+		case 9:
+			action = event.ActionTarget
+		case 10:
+			action = event.ActionFumen
+		case 11:
+			action = event.ActionRetry
 		default:
 			log.Fatal("setting keybind for unknown action")
 		}
@@ -152,7 +354,7 @@ func handleKeypress(ev *tcell.EventKey) *tcell.EventKey {
 					renderGameList()
 					updateTitle()
 					return nil
-				} else if currentScreen == screenGames {
+				} else if currentScreen == screenGames || currentScreen == screenPractice {
 					currentScreen = screenTitle
 				} else {
 					currentScreen = screenSettings
@@ -190,8 +392,8 @@ func handleKeypress(ev *tcell.EventKey) *tcell.EventKey {
 				switch k {
 				case tcell.KeyTab:
 					currentSelection++
-					if currentSelection > 8 {
-						currentSelection = 8
+					if currentSelection > 13 {
+						currentSelection = 13
 					}
 
 					updateTitle()
@@ -260,7 +462,7 @@ func handleKeypress(ev *tcell.EventKey) *tcell.EventKey {
 						}
 					}
 				}
-			} else if currentScreen == screenNewGame {
+			} else if currentScreen == screenNewGame || currentScreen == screenPractice {
 				switch k {
 				case tcell.KeyBacktab:
 					previousTitleButton()
@@ -450,12 +652,21 @@ func rotateCW(ev *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func rotate180(ev *tcell.EventKey) *tcell.EventKey {
+	if activeGame == nil {
+		return ev
+	}
+
+	activeGame.ProcessAction(event.ActionRotate180)
+	return nil
+}
+
 func moveLeft(ev *tcell.EventKey) *tcell.EventKey {
 	if activeGame == nil {
 		return ev
 	}
 
-	activeGame.ProcessAction(event.ActionMoveLeft)
+	pressShiftKey(event.ActionMoveLeft)
 	return nil
 }
 
@@ -464,7 +675,7 @@ func moveRight(ev *tcell.EventKey) *tcell.EventKey {
 		return ev
 	}
 
-	activeGame.ProcessAction(event.ActionMoveRight)
+	pressShiftKey(event.ActionMoveRight)
 	return nil
 }
 
@@ -473,7 +684,7 @@ func softDrop(ev *tcell.EventKey) *tcell.EventKey {
 		return ev
 	}
 
-	activeGame.ProcessAction(event.ActionSoftDrop)
+	pressShiftKey(event.ActionSoftDrop)
 	return nil
 }
 
@@ -485,3 +696,39 @@ func hardDrop(ev *tcell.EventKey) *tcell.EventKey {
 	activeGame.ProcessAction(event.ActionHardDrop)
 	return nil
 }
+
+func hold(ev *tcell.EventKey) *tcell.EventKey {
+	if activeGame == nil {
+		return ev
+	}
+
+	activeGame.ProcessAction(event.ActionHold)
+	return nil
+}
+
+func target(ev *tcell.EventKey) *tcell.EventKey {
+	if activeGame == nil {
+		return ev
+	}
+
+	activeGame.ProcessAction(event.ActionTarget)
+	return nil
+}
+
+func exportFumen(ev *tcell.EventKey) *tcell.EventKey {
+	if activeGame == nil {
+		return ev
+	}
+
+	activeGame.ProcessAction(event.ActionFumen)
+	return nil
+}
+
+func retry(ev *tcell.EventKey) *tcell.EventKey {
+	if activeGame == nil {
+		return ev
+	}
+
+	activeGame.ProcessAction(event.ActionRetry)
+	return nil
+}
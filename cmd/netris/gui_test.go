@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
 	"code.rocket9labs.com/tslocum/netris/pkg/mino"
 )
 
@@ -63,3 +65,61 @@ func BenchmarkRenderMatrix(b *testing.B) {
 
 	blockSize = 1
 }
+
+func TestShiftKey(t *testing.T) {
+	start := time.Now()
+	k := &shiftKey{action: event.ActionMoveLeft, pressed: start}
+
+	if repeat, held := k.press(start); repeat || held {
+		t.Errorf("failed to press key: got repeat %v, held %v", repeat, held)
+	}
+
+	// Terminals wait before repeating a held key
+	now := start.Add(500 * time.Millisecond)
+	if repeat, held := k.press(now); repeat || held {
+		t.Errorf("failed to press key after repeat delay: got repeat %v, held %v", repeat, held)
+	}
+
+	now = now.Add(30 * time.Millisecond)
+	if repeat, held := k.press(now); !repeat || !held {
+		t.Errorf("failed to hold key: got repeat %v, held %v", repeat, held)
+	}
+
+	now = now.Add(30 * time.Millisecond)
+	if repeat, held := k.press(now); !repeat || held {
+		t.Errorf("failed to repeat held key: got repeat %v, held %v", repeat, held)
+	}
+
+	if k.released(now.Add(30 * time.Millisecond)) {
+		t.Error("failed to keep key held between repeats")
+	} else if !k.released(now.Add(keyRepeatWindow)) {
+		t.Error("failed to release key")
+	}
+}
+
+func TestAddDefaultKeyBinds(t *testing.T) {
+	input := map[event.GameAction][]string{
+		event.ActionRotateCCW: {"a"},
+		event.ActionRotateCW:  {"x", "X", "C"},
+		event.ActionHardDrop:  {"Up", "t"},
+	}
+
+	addDefaultKeyBinds(input)
+
+	testCases := []struct {
+		action event.GameAction
+		keys   []string
+	}{
+		{event.ActionRotateCCW, []string{"a"}},
+		{event.ActionRotate180, []string{"A"}},
+		{event.ActionHold, []string{"c"}},
+		{event.ActionTarget, []string{"T"}},
+		{event.ActionMoveLeft, []string{"Left", "h", "H"}},
+	}
+
+	for i, tc := range testCases {
+		if keys := fmt.Sprint(input[tc.action]); keys != fmt.Sprint(tc.keys) {
+			t.Errorf("case %d: failed to add default keybinds for %s: expected %v, got %s", i, tc.action, tc.keys, keys)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.rocket9labs.com/tslocum/netris/pkg/event"
@@ -27,6 +28,7 @@ const (
 	screenGameSettings
 	screenGames
 	screenNewGame
+	screenPractice
 )
 
 var (
@@ -56,6 +58,9 @@ var (
 	gameListContainerGrid *cview.Grid
 	newGameContainerGrid  *cview.Grid
 
+	practiceGrid          *cview.Grid
+	practiceContainerGrid *cview.Grid
+
 	gameSettingsGrid          *cview.Grid
 	gameSettingsContainerGrid *cview.Grid
 	gameGrid                  *cview.Grid
@@ -79,6 +84,140 @@ var (
 	buttonLabelC *cview.TextView
 )
 
+// newGameOption is a game rule selected on the new game screen by cycling
+// through its values.
+type newGameOption struct {
+	label    string
+	values   []string
+	selected int
+	button   *cview.Button
+}
+
+const (
+	newGameOptionRotation = iota
+	newGameOptionGravity
+	newGameOptionPieces
+	newGameOptionRandomizer
+	newGameOptionGarbage
+	newGameOptionMessiness
+	newGameOptionSize
+	newGameOptionPerfectClear
+	newGameOptionTargeting
+	newGameOptionLockDelay
+	newGameOptionLockReset
+)
+
+var newGameOptions = []*newGameOption{
+	newGameOptionRotation:     {label: "Rotation", values: []string{mino.RotationClassic.String(), mino.RotationSRS.String()}},
+	newGameOptionGravity:      {label: "Gravity", values: []string{mino.GravityStatic.String(), mino.GravityLevel.String(), mino.GravityTime.String()}},
+	newGameOptionPieces:       {label: "Pieces", values: []string{"Tetromino", "Tromino", "Pentomino", "Mixed"}},
+	newGameOptionRandomizer:   {label: "Randomizer", values: []string{mino.RandomizerBag.String(), mino.RandomizerBag14.String(), mino.RandomizerRandom.String(), mino.RandomizerHistory.String()}},
+	newGameOptionGarbage:      {label: "Garbage", values: []string{mino.GarbageCheese.String(), mino.GarbageClean.String()}},
+	newGameOptionMessiness:    {label: "Messiness", values: []string{mino.MessinessRandom.String(), "30% Per Line", "10% Per Line", "50% Per Line", "Per Attack"}},
+	newGameOptionSize:         {label: "Size", values: []string{"10x20", "4x20", "8x16", "12x24"}},
+	newGameOptionPerfectClear: {label: "Perfect Clear", values: []string{"10 Lines", "6 Lines", "4 Lines", "None"}},
+	newGameOptionTargeting:    {label: "Targeting", values: []string{game.TargetEven.String(), game.TargetRandom.String(), game.TargetAttackers.String(), game.TargetKO.String(), game.TargetManual.String()}},
+	newGameOptionLockDelay:    {label: "Lock Delay", values: []string{"500ms", "250ms", "1000ms"}},
+	newGameOptionLockReset:    {label: "Lock Reset", values: []string{"15 Moves", "30 Moves", mino.LockResetInfinite.String(), mino.LockResetStep.String(), mino.LockResetNone.String()}},
+}
+
+// Garbage messiness and hole change chance for each value of the messiness
+// option
+var (
+	newGameMessiness      = []mino.GarbageMessiness{mino.MessinessRandom, mino.MessinessChance, mino.MessinessChance, mino.MessinessChance, mino.MessinessAttack}
+	newGameGarbageChances = []int{0, 0, 10, 50, 0}
+)
+
+// Matrix dimensions for each value of the size option
+var newGameSizes = []mino.Point{{10, 20}, {4, 20}, {8, 16}, {12, 24}}
+
+// Bonus garbage for each value of the perfect clear option
+var newGamePerfectClears = []int{0, 6, 4, mino.PerfectClearNone}
+
+// Lock delay in milliseconds for each value of the lock delay option
+var newGameLockDelays = []int{0, 250, 1000}
+
+// Lock reset and reset limit for each value of the lock reset option
+var (
+	newGameLockResets  = []mino.LockReset{mino.LockResetMove, mino.LockResetMove, mino.LockResetInfinite, mino.LockResetStep, mino.LockResetNone}
+	newGameResetLimits = []int{0, 30, 0, 0, 0}
+)
+
+// Ranks of minos played for each value of the pieces option
+var newGamePiecesRanks = [][]int{{4}, {3}, {5}, {3, 4, 5}}
+
+// Selection index of the first new game option
+const newGameOptionsStart = 3
+
+const (
+	practiceOptionMode = iota
+	practiceOptionGoal
+)
+
+// Modes for each value of the practice mode option
+var practiceModes = []game.Mode{game.ModeEndless, game.ModeSprint, game.ModeUltra, game.ModeMarathon, game.ModeDig}
+
+var practiceOptions = []*newGameOption{
+	practiceOptionMode: {label: "Mode", values: []string{game.ModeEndless.String(), game.ModeSprint.String(), game.ModeUltra.String(), game.ModeMarathon.String(), game.ModeDig.String()}},
+	practiceOptionGoal: {label: "Goal", values: practiceGoalValues(game.ModeEndless)},
+}
+
+func (o *newGameOption) cycle() {
+	o.selected++
+	if o.selected == len(o.values) {
+		o.selected = 0
+	}
+	o.button.SetLabel(o.values[o.selected])
+}
+
+func (o *newGameOption) reset() {
+	o.selected = 0
+	o.button.SetLabel(o.values[o.selected])
+}
+
+func newGameRanks() []int {
+	return newGamePiecesRanks[newGameOptions[newGameOptionPieces].selected]
+}
+
+func newGameTargeting() game.Targeting {
+	return game.Targeting(newGameOptions[newGameOptionTargeting].selected)
+}
+
+func newGameRules() mino.Rules {
+	return mino.Rules{
+		RotationSystem: mino.RotationSystem(newGameOptions[newGameOptionRotation].selected),
+		Gravity:        mino.Gravity(newGameOptions[newGameOptionGravity].selected),
+		Randomizer:     mino.RandomizerType(newGameOptions[newGameOptionRandomizer].selected),
+		Garbage:        mino.GarbageStyle(newGameOptions[newGameOptionGarbage].selected),
+		Messiness:      newGameMessiness[newGameOptions[newGameOptionMessiness].selected],
+		GarbageChance:  newGameGarbageChances[newGameOptions[newGameOptionMessiness].selected],
+		Width:          newGameSizes[newGameOptions[newGameOptionSize].selected].X,
+		Height:         newGameSizes[newGameOptions[newGameOptionSize].selected].Y,
+		PerfectClear:   newGamePerfectClears[newGameOptions[newGameOptionPerfectClear].selected],
+		LockDelay:      newGameLockDelays[newGameOptions[newGameOptionLockDelay].selected],
+		LockReset:      newGameLockResets[newGameOptions[newGameOptionLockReset].selected],
+		ResetLimit:     newGameResetLimits[newGameOptions[newGameOptionLockReset].selected],
+	}
+}
+
+// practiceGoalValues returns the values of the practice goal option when
+// playing the specified mode.
+func practiceGoalValues(mode game.Mode) []string {
+	var values []string
+	for _, goal := range game.ModeGoals[mode] {
+		values = append(values, mode.GoalString(goal))
+	}
+	return values
+}
+
+func practiceMode() game.Mode {
+	return practiceModes[practiceOptions[practiceOptionMode].selected]
+}
+
+func practiceGoal() int {
+	return game.ModeGoals[practiceMode()][practiceOptions[practiceOptionGoal].selected]
+}
+
 func previousTitleButton() {
 	if currentSelection == 0 {
 		return
@@ -92,7 +231,9 @@ func nextTitleButton() {
 	if currentScreen == screenGames {
 		maxButton = 3
 	} else if currentScreen == screenNewGame {
-		maxButton = 4
+		maxButton = newGameOptionsStart + len(newGameOptions) + 1
+	} else if currentScreen == screenPractice {
+		maxButton = len(practiceOptions) + 1
 	}
 	if currentSelection >= maxButton {
 		return
@@ -155,8 +296,8 @@ func selectTitleButton() {
 			drawGhostPieceUnsaved = !drawGhostPieceUnsaved
 			updateTitle()
 			return
-		} else if currentSelection == 7 || currentSelection == 8 {
-			if currentSelection == 8 {
+		} else if currentSelection == 12 || currentSelection == 13 {
+			if currentSelection == 13 {
 				drawGhostPiece = drawGhostPieceUnsaved
 
 				for _, bind := range draftKeybindings {
@@ -227,16 +368,36 @@ func selectTitleButton() {
 			updateTitle()
 		}
 	case screenNewGame:
-		if currentSelection == 3 {
+		if currentSelection >= newGameOptionsStart && currentSelection < newGameOptionsStart+len(newGameOptions) {
+			newGameOptions[currentSelection-newGameOptionsStart].cycle()
+		} else if currentSelection == newGameOptionsStart+len(newGameOptions) {
 			currentScreen = screenGames
 			gameListSelected = 0
 			currentSelection = 0
 			app.SetRoot(gameListContainerGrid, true)
 			renderGameList()
 			updateTitle()
-		} else if currentSelection == 4 {
+		} else if currentSelection == newGameOptionsStart+len(newGameOptions)+1 {
 			joinGame <- event.GameIDNewCustom
 		}
+	case screenPractice:
+		if currentSelection < len(practiceOptions) {
+			practiceOptions[currentSelection].cycle()
+
+			if currentSelection == practiceOptionMode {
+				goal := practiceOptions[practiceOptionGoal]
+				goal.values = practiceGoalValues(practiceMode())
+				goal.reset()
+			}
+		} else if currentSelection == len(practiceOptions) {
+			currentScreen = screenTitle
+			currentSelection = 0
+
+			app.SetRoot(titleContainerGrid, true)
+			updateTitle()
+		} else if currentSelection == len(practiceOptions)+1 {
+			joinGame <- event.GameIDNewLocal
+		}
 	default: // Title screen 0
 		if joinedGame {
 			switch currentSelection {
@@ -264,7 +425,12 @@ func selectTitleButton() {
 				app.SetFocus(nil)
 				updateTitle()
 			case 1:
-				joinGame <- event.GameIDNewLocal
+				currentScreen = screenPractice
+				currentSelection = 0
+
+				app.SetRoot(practiceContainerGrid, true)
+				app.SetFocus(nil)
+				updateTitle()
 			case 2:
 				currentScreen = screenSettings
 				currentSelection = 0
@@ -363,16 +529,26 @@ func updateTitle() {
 		case 2:
 			app.SetFocus(buttonKeybindRotateCW)
 		case 3:
-			app.SetFocus(buttonKeybindMoveLeft)
+			app.SetFocus(buttonKeybindRotate180)
 		case 4:
-			app.SetFocus(buttonKeybindMoveRight)
+			app.SetFocus(buttonKeybindMoveLeft)
 		case 5:
-			app.SetFocus(buttonKeybindSoftDrop)
+			app.SetFocus(buttonKeybindMoveRight)
 		case 6:
-			app.SetFocus(buttonKeybindHardDrop)
+			app.SetFocus(buttonKeybindSoftDrop)
 		case 7:
-			app.SetFocus(buttonKeybindCancel)
+			app.SetFocus(buttonKeybindHardDrop)
 		case 8:
+			app.SetFocus(buttonKeybindHold)
+		case 9:
+			app.SetFocus(buttonKeybindTarget)
+		case 10:
+			app.SetFocus(buttonKeybindFumen)
+		case 11:
+			app.SetFocus(buttonKeybindRetry)
+		case 12:
+			app.SetFocus(buttonKeybindCancel)
+		case 13:
 			app.SetFocus(buttonKeybindSave)
 		}
 		return
@@ -390,16 +566,28 @@ func updateTitle() {
 		return
 	case screenNewGame:
 		switch currentSelection {
+		case 0:
+			app.SetFocus(newGameNameInput)
 		case 1:
 			app.SetFocus(newGameMaxPlayersInput)
 		case 2:
 			app.SetFocus(newGameSpeedLimitInput)
-		case 3:
+		case newGameOptionsStart + len(newGameOptions):
 			app.SetFocus(buttonNewGameCancel)
-		case 4:
+		case newGameOptionsStart + len(newGameOptions) + 1:
 			app.SetFocus(buttonNewGameStart)
 		default:
-			app.SetFocus(newGameNameInput)
+			app.SetFocus(newGameOptions[currentSelection-newGameOptionsStart].button)
+		}
+		return
+	case screenPractice:
+		switch currentSelection {
+		case len(practiceOptions):
+			app.SetFocus(buttonPracticeCancel)
+		case len(practiceOptions) + 1:
+			app.SetFocus(buttonPracticeStart)
+		default:
+			app.SetFocus(practiceOptions[currentSelection].button)
 		}
 		return
 	default:
@@ -530,7 +718,8 @@ func renderGameList() {
 	gameListView.Write([]byte(fmt.Sprintf("%-27s%s", "Game", "Players")))
 	gameListView.Write([]byte("\n"))
 
-	h := 10
+	// Leave room for the details of the selected game
+	h := 7
 
 	for i, g := range gameList {
 		p := strconv.Itoa(g.Players)
@@ -556,7 +745,57 @@ func renderGameList() {
 				gameListView.Write([]byte(" "))
 			}
 		}
+
+		gameListView.Write([]byte("\n"))
+	}
+
+	if gameListSelected >= 0 && gameListSelected < len(gameList) {
+		gameListView.Write([]byte(gameListDetails(gameList[gameListSelected])))
+	}
+}
+
+// gameListDetails returns a summary of the rules of a listed game.
+func gameListDetails(g *game.ListedGame) string {
+	w, h := g.Size()
+
+	var reset string
+	switch g.LockReset {
+	case mino.LockResetMove:
+		reset = fmt.Sprintf("%d move resets", g.Resets())
+	case mino.LockResetInfinite:
+		reset = "infinite resets"
+	case mino.LockResetStep:
+		reset = "step reset"
+	default:
+		reset = "no reset"
+	}
+
+	var holes string
+	switch g.Messiness {
+	case mino.MessinessChance:
+		holes = fmt.Sprintf("%d%% hole change", g.HoleChance())
+	case mino.MessinessAttack:
+		holes = "hole per attack"
+	default:
+		holes = "random holes"
 	}
+
+	ranks := game.ValidRanks(g.Ranks)
+	rankLabel := "Rank"
+	if len(ranks) > 1 {
+		rankLabel = "Ranks"
+	}
+	rankValues := make([]string, len(ranks))
+	for i, rank := range ranks {
+		rankValues[i] = strconv.Itoa(rank)
+	}
+
+	return fmt.Sprintf("%s rotation, %dx%d\n%s %s, %s randomizer\n%s garbage, %s\n%s targeting\nLock delay %dms, %s",
+		g.RotationSystem, w, h,
+		rankLabel, strings.Join(rankValues, ","), g.Randomizer,
+		g.Garbage, holes,
+		g.Targeting,
+		g.LockDelayTime().Milliseconds(), reset)
 }
 
 func refreshGameList() {
@@ -622,6 +861,10 @@ func resetNewGameInputs() {
 	newGameNameInput.SetText("netris")
 	newGameMaxPlayersInput.SetText("0")
 	newGameSpeedLimitInput.SetText("0")
+
+	for _, o := range newGameOptions {
+		o.reset()
+	}
 }
 
 func selectTitleFunc(i int) func() {
@@ -37,6 +37,8 @@ var (
 	serverAddress  string
 	debugAddress   string
 	startMatrix    string
+	startFumen     string
+	startPuzzle    string
 
 	nicknameFlag string
 
@@ -76,6 +78,8 @@ func main() {
 	flag.IntVar(&blockSize, "scale", 0, "UI scale")
 	flag.StringVar(&nicknameFlag, "nick", "", "nickname")
 	flag.StringVar(&startMatrix, "matrix", "", "pre-fill matrix with pieces")
+	flag.StringVar(&startFumen, "fumen", "", "pre-fill matrix with fumen board")
+	flag.StringVar(&startPuzzle, "puzzle", "", "path to puzzle file")
 	flag.StringVar(&connectAddress, "connect", "", "connect to server address or socket path")
 	flag.StringVar(&serverAddress, "server", game.DefaultServer, "server address or socket path")
 	flag.StringVar(&debugAddress, "debug-address", "", "address to serve debug info")
@@ -143,6 +147,25 @@ func main() {
 		}
 	}
 	setBorderColor(config.Colors[event.GameColorBorder])
+	setMeterColors(config.Colors[event.GameColorMeter], config.Colors[event.GameColorMeterWaiting])
+
+	if config.NextPieces < 1 {
+		config.NextPieces = 1
+	} else if config.NextPieces > game.MaxNextPieces {
+		config.NextPieces = game.MaxNextPieces
+	}
+
+	if config.DAS < 0 {
+		config.DAS = 0
+	}
+	if config.ARR < 0 {
+		config.ARR = 0
+	}
+	if config.SDF < 1 {
+		config.SDF = 1
+	} else if config.SDF > MaxSDF {
+		config.SDF = MaxSDF
+	}
 
 	if nicknameFlag != "" && game.Nickname(nicknameFlag) != "" {
 		config.Name = game.Nickname(nicknameFlag)
@@ -257,7 +280,7 @@ func main() {
 					speedLimit = 0
 				}
 
-				newGame = &game.ListedGame{Name: game.GameName(newGameNameInput.GetText()), MaxPlayers: maxPlayers, SpeedLimit: speedLimit}
+				newGame = &game.ListedGame{Name: game.GameName(newGameNameInput.GetText()), MaxPlayers: maxPlayers, SpeedLimit: speedLimit, Ranks: newGameRanks(), Targeting: newGameTargeting(), Rules: newGameRules()}
 			}
 
 			activeGame, err = activeGameConn.JoinGame(config.Name, gameID, newGame, logger, draw)
@@ -270,6 +293,9 @@ func main() {
 			}
 
 			activeGame.LogLevel = logLevel
+			activeGame.FumenPath = path.Join(path.Dir(configPath), game.FumenFile)
+			activeGame.SetNextPieces(config.NextPieces)
+			app.QueueUpdateDraw(resize)
 			continue
 		}
 
@@ -306,12 +332,22 @@ func main() {
 			log.Fatalf("failed to create local game: %s", err)
 		}
 
-		activeGame, err = activeGameConn.JoinGame(config.Name, event.GameIDNewLocal, nil, logger, draw)
+		// Puzzles are played without a mode
+		newGame := &game.ListedGame{Mode: practiceMode(), ModeGoal: practiceGoal()}
+		if startPuzzle != "" {
+			newGame = &game.ListedGame{Puzzle: true}
+		}
+
+		activeGame, err = activeGameConn.JoinGame(config.Name, event.GameIDNewLocal, newGame, logger, draw)
 		if err != nil {
 			log.Fatalf("failed to join local game: %s", err)
 		}
 
 		activeGame.LogLevel = logLevel
+		activeGame.FumenPath = path.Join(path.Dir(configPath), game.FumenFile)
+		activeGame.RecordsPath = path.Join(path.Dir(configPath), game.RecordsFile)
+		activeGame.SetNextPieces(config.NextPieces)
+		app.QueueUpdateDraw(resize)
 
 		if startMatrix != "" {
 			activeGame.Players[activeGame.LocalPlayer].Matrix.Lock()
@@ -335,5 +371,26 @@ func main() {
 			}
 			activeGame.Players[activeGame.LocalPlayer].Matrix.Unlock()
 		}
+
+		if startFumen != "" {
+			err = activeGame.Players[activeGame.LocalPlayer].Matrix.LoadFumen(startFumen)
+			if err != nil {
+				log.Fatalf("failed to load fumen: %s", err)
+			}
+			startFumen = ""
+		}
+
+		if startPuzzle != "" {
+			puzzle, err := mino.LoadPuzzle(startPuzzle)
+			if err != nil {
+				log.Fatalf("failed to load puzzle: %s", err)
+			}
+
+			err = activeGame.SetPuzzle(puzzle)
+			if err != nil {
+				log.Fatalf("failed to start puzzle: %s", err)
+			}
+			startPuzzle = ""
+		}
 	}
 }
@@ -6,10 +6,15 @@ const (
 	ActionUnknown   = ""
 	ActionRotateCCW = "rotate-ccw"
 	ActionRotateCW  = "rotate-cw"
+	ActionRotate180 = "rotate-180"
 	ActionMoveLeft  = "move-left"
 	ActionMoveRight = "move-right"
 	ActionSoftDrop  = "soft-drop"
 	ActionHardDrop  = "hard-drop"
+	ActionHold      = "hold"
+	ActionTarget    = "target"
+	ActionFumen     = "fumen"
+	ActionRetry     = "retry"
 	ActionPing      = "ping"
 	ActionStats     = "stats"
 	ActionNick      = "nick"
@@ -3,39 +3,131 @@ package event
 type GameColor string
 
 const (
-	GameColorI       = "i"
-	GameColorO       = "o"
-	GameColorT       = "t"
-	GameColorJ       = "j"
-	GameColorL       = "l"
-	GameColorS       = "s"
-	GameColorZ       = "z"
-	GameColorIGhost  = "i-ghost"
-	GameColorOGhost  = "o-ghost"
-	GameColorTGhost  = "t-ghost"
-	GameColorJGhost  = "j-ghost"
-	GameColorLGhost  = "l-ghost"
-	GameColorSGhost  = "s-ghost"
-	GameColorZGhost  = "z-ghost"
-	GameColorGarbage = "garbage"
-	GameColorBorder  = "border"
+	GameColorI            = "i"
+	GameColorO            = "o"
+	GameColorT            = "t"
+	GameColorJ            = "j"
+	GameColorL            = "l"
+	GameColorS            = "s"
+	GameColorZ            = "z"
+	GameColorIGhost       = "i-ghost"
+	GameColorOGhost       = "o-ghost"
+	GameColorTGhost       = "t-ghost"
+	GameColorJGhost       = "j-ghost"
+	GameColorLGhost       = "l-ghost"
+	GameColorSGhost       = "s-ghost"
+	GameColorZGhost       = "z-ghost"
+	GameColorExtra1       = "extra-1"
+	GameColorExtra2       = "extra-2"
+	GameColorExtra3       = "extra-3"
+	GameColorExtra4       = "extra-4"
+	GameColorExtra5       = "extra-5"
+	GameColorExtra6       = "extra-6"
+	GameColorExtra7       = "extra-7"
+	GameColorExtra8       = "extra-8"
+	GameColorExtra9       = "extra-9"
+	GameColorExtra10      = "extra-10"
+	GameColorExtra11      = "extra-11"
+	GameColorExtra12      = "extra-12"
+	GameColorExtra13      = "extra-13"
+	GameColorExtra14      = "extra-14"
+	GameColorExtra15      = "extra-15"
+	GameColorExtra16      = "extra-16"
+	GameColorExtra17      = "extra-17"
+	GameColorExtra18      = "extra-18"
+	GameColorExtra19      = "extra-19"
+	GameColorExtra20      = "extra-20"
+	GameColorExtra21      = "extra-21"
+	GameColorExtra22      = "extra-22"
+	GameColorExtra1Ghost  = "extra-1-ghost"
+	GameColorExtra2Ghost  = "extra-2-ghost"
+	GameColorExtra3Ghost  = "extra-3-ghost"
+	GameColorExtra4Ghost  = "extra-4-ghost"
+	GameColorExtra5Ghost  = "extra-5-ghost"
+	GameColorExtra6Ghost  = "extra-6-ghost"
+	GameColorExtra7Ghost  = "extra-7-ghost"
+	GameColorExtra8Ghost  = "extra-8-ghost"
+	GameColorExtra9Ghost  = "extra-9-ghost"
+	GameColorExtra10Ghost = "extra-10-ghost"
+	GameColorExtra11Ghost = "extra-11-ghost"
+	GameColorExtra12Ghost = "extra-12-ghost"
+	GameColorExtra13Ghost = "extra-13-ghost"
+	GameColorExtra14Ghost = "extra-14-ghost"
+	GameColorExtra15Ghost = "extra-15-ghost"
+	GameColorExtra16Ghost = "extra-16-ghost"
+	GameColorExtra17Ghost = "extra-17-ghost"
+	GameColorExtra18Ghost = "extra-18-ghost"
+	GameColorExtra19Ghost = "extra-19-ghost"
+	GameColorExtra20Ghost = "extra-20-ghost"
+	GameColorExtra21Ghost = "extra-21-ghost"
+	GameColorExtra22Ghost = "extra-22-ghost"
+	GameColorGarbage      = "garbage"
+	GameColorBorder       = "border"
+	GameColorMeter        = "meter"
+	GameColorMeterWaiting = "meter-waiting"
 )
 
 var DefaultColors = map[GameColor]string{
-	GameColorJ:       "#2864ff",
-	GameColorI:       "#00eeee",
-	GameColorZ:       "#ee0000",
-	GameColorO:       "#dddd00",
-	GameColorT:       "#c000cc",
-	GameColorS:       "#00e900",
-	GameColorL:       "#ff7308",
-	GameColorJGhost:  "#6e7bc3",
-	GameColorIGhost:  "#6bbaba",
-	GameColorZGhost:  "#ba6b6b",
-	GameColorOGhost:  "#b1b16b",
-	GameColorTGhost:  "#a16ba8",
-	GameColorSGhost:  "#6bb76b",
-	GameColorLGhost:  "#c3806c",
-	GameColorGarbage: "#999999",
-	GameColorBorder:  "#444444",
+	GameColorJ:            "#2864ff",
+	GameColorI:            "#00eeee",
+	GameColorZ:            "#ee0000",
+	GameColorO:            "#dddd00",
+	GameColorT:            "#c000cc",
+	GameColorS:            "#00e900",
+	GameColorL:            "#ff7308",
+	GameColorJGhost:       "#6e7bc3",
+	GameColorIGhost:       "#6bbaba",
+	GameColorZGhost:       "#ba6b6b",
+	GameColorOGhost:       "#b1b16b",
+	GameColorTGhost:       "#a16ba8",
+	GameColorSGhost:       "#6bb76b",
+	GameColorLGhost:       "#c3806c",
+	GameColorExtra1:       "#ff5fa0",
+	GameColorExtra2:       "#8a5cff",
+	GameColorExtra3:       "#00b38f",
+	GameColorExtra4:       "#a0e000",
+	GameColorExtra5:       "#ffb400",
+	GameColorExtra6:       "#00a0ff",
+	GameColorExtra7:       "#b86a3c",
+	GameColorExtra8:       "#ff8a8a",
+	GameColorExtra9:       "#5070c0",
+	GameColorExtra10:      "#d2a0ff",
+	GameColorExtra11:      "#90a840",
+	GameColorExtra12:      "#ffd0a0",
+	GameColorExtra13:      "#60ffb0",
+	GameColorExtra14:      "#c04080",
+	GameColorExtra15:      "#f0f080",
+	GameColorExtra16:      "#a0ffff",
+	GameColorExtra17:      "#806000",
+	GameColorExtra18:      "#ff60ff",
+	GameColorExtra19:      "#7020b0",
+	GameColorExtra20:      "#e0e0e0",
+	GameColorExtra21:      "#c0ff80",
+	GameColorExtra22:      "#b00030",
+	GameColorExtra1Ghost:  "#c96990",
+	GameColorExtra2Ghost:  "#8267c9",
+	GameColorExtra3Ghost:  "#309b85",
+	GameColorExtra4Ghost:  "#90b630",
+	GameColorExtra5Ghost:  "#c99c30",
+	GameColorExtra6Ghost:  "#3090c9",
+	GameColorExtra7Ghost:  "#9e6f54",
+	GameColorExtra8Ghost:  "#c98282",
+	GameColorExtra9Ghost:  "#6073a3",
+	GameColorExtra10Ghost: "#ae90c9",
+	GameColorExtra11Ghost: "#869456",
+	GameColorExtra12Ghost: "#c9ac90",
+	GameColorExtra13Ghost: "#69c999",
+	GameColorExtra14Ghost: "#a3567c",
+	GameColorExtra15Ghost: "#c0c07c",
+	GameColorExtra16Ghost: "#90c9c9",
+	GameColorExtra17Ghost: "#7c6930",
+	GameColorExtra18Ghost: "#c969c9",
+	GameColorExtra19Ghost: "#734399",
+	GameColorExtra20Ghost: "#b6b6b6",
+	GameColorExtra21Ghost: "#a3c97c",
+	GameColorExtra22Ghost: "#99304c",
+	GameColorGarbage:      "#999999",
+	GameColorBorder:       "#444444",
+	GameColorMeter:        "#ee0000",
+	GameColorMeterWaiting: "#dddd00",
 }
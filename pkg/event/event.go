@@ -28,6 +28,15 @@ type ScoreEvent struct {
 	Score int
 }
 
+type PerfectClearEvent struct {
+	Event
+}
+
+type PuzzleEvent struct {
+	Event
+	Solved bool
+}
+
 type SendGarbageEvent struct {
 	Event
 	Lines int
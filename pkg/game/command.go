@@ -28,6 +28,8 @@ const (
 	CommandReceiveGarbage
 	CommandStats
 	CommandListGames
+	CommandPerfectClear
+	CommandSetTarget
 )
 
 func (c Command) String() string {
@@ -66,6 +68,10 @@ func (c Command) String() string {
 		return "Stats"
 	case CommandListGames:
 		return "ListGames"
+	case CommandPerfectClear:
+		return "PerfectClear"
+	case CommandSetTarget:
+		return "SetTarget"
 	default:
 		return strconv.Itoa(int(c))
 	}
@@ -224,6 +230,23 @@ func (gc GameCommandReceiveGarbage) Command() Command {
 	return CommandReceiveGarbage
 }
 
+type GameCommandPerfectClear struct {
+	GameCommand
+}
+
+func (gc GameCommandPerfectClear) Command() Command {
+	return CommandPerfectClear
+}
+
+type GameCommandSetTarget struct {
+	GameCommand
+	Target int `json:"t,omitempty"`
+}
+
+func (gc GameCommandSetTarget) Command() Command {
+	return CommandSetTarget
+}
+
 type GameCommandStats struct {
 	GameCommand
 	Created time.Time `json:"c,omitempty"`
@@ -237,10 +260,17 @@ func (gc GameCommandStats) Command() Command {
 
 type ListedGame struct {
 	ID         int
-	Name       string `json:"n,omitempty"`
-	Players    int    `json:"p,omitempty"`
-	MaxPlayers int    `json:"pl,omitempty"`
-	SpeedLimit int    `json:"sl,omitempty"`
+	Name       string    `json:"n,omitempty"`
+	Players    int       `json:"p,omitempty"`
+	MaxPlayers int       `json:"pl,omitempty"`
+	SpeedLimit int       `json:"sl,omitempty"`
+	Ranks      []int     `json:"rk,omitempty"`
+	Targeting  Targeting `json:"tg,omitempty"`
+	Mode       Mode      `json:"md,omitempty"`
+	ModeGoal   int       `json:"mg,omitempty"`
+	Puzzle     bool      `json:"pz,omitempty"`
+
+	mino.Rules
 }
 type GameCommandListGames struct {
 	GameCommand
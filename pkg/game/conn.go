@@ -226,6 +226,14 @@ func (s *Conn) handleRead() {
 			var mgc GameCommandListGames
 			um(&mgc)
 			gc = &mgc
+		case CommandPerfectClear:
+			var mgc GameCommandPerfectClear
+			um(&mgc)
+			gc = &mgc
+		case CommandSetTarget:
+			var mgc GameCommandSetTarget
+			um(&mgc)
+			gc = &mgc
 		default:
 			// TODO Require at least debug log level
 			log.Println("unknown serverconn command", scanner.Text())
@@ -332,6 +340,12 @@ func (s *Conn) JoinGame(name string, gameID int, newGame *ListedGame, logger cha
 		joinGameCommand.Listing.Name = newGame.Name
 		joinGameCommand.Listing.MaxPlayers = newGame.MaxPlayers
 		joinGameCommand.Listing.SpeedLimit = newGame.SpeedLimit
+		joinGameCommand.Listing.Ranks = newGame.Ranks
+		joinGameCommand.Listing.Targeting = newGame.Targeting
+		joinGameCommand.Listing.Mode = newGame.Mode
+		joinGameCommand.Listing.ModeGoal = newGame.ModeGoal
+		joinGameCommand.Listing.Puzzle = newGame.Puzzle
+		joinGameCommand.Listing.Rules = newGame.Rules
 	}
 	s.Write(&joinGameCommand)
 
@@ -363,14 +377,22 @@ func (s *Conn) JoinGame(name string, gameID int, newGame *ListedGame, logger cha
 			}
 		case CommandJoinGame:
 			if p, ok := e.(*GameCommandJoinGame); ok {
-				g, err = NewGame(4, s.Write, logger, draw)
+				g, err = NewGame(DefaultRank, s.Write, logger, draw)
 				if err != nil {
 					return nil, err
 				}
 
 				g.Lock()
 				g.LocalPlayer = p.PlayerID
+				g.Rules = p.Listing.Rules
+				g.Targeting = p.Listing.Targeting
+				g.Mode = p.Listing.Mode
+				g.ModeGoal = p.Listing.ModeGoal
+				err = g.SetRanksL(p.Listing.Ranks)
 				g.Unlock()
+				if err != nil {
+					return nil, err
+				}
 			}
 		case CommandUpdateGame:
 			if g == nil {
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+// FumenFile is the name of the file which stores the last exported board, saved
+// alongside the client configuration.
+const FumenFile = "fumen.txt"
+
+// FumenURL is the address of the viewer which exported boards are linked to.
+const FumenURL = "https://harddrop.com/fumen/?"
+
+// SaveFumen writes a fumen and a link to view it to a file, replacing the board
+// previously exported.
+func SaveFumen(p string, fumen string) error {
+	err := os.MkdirAll(path.Dir(p), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(p, []byte(fumen+"\n"+FumenURL+fumen+"\n"), 0600)
+}
+
+// exportFumenL saves the board of a player as a fumen, or prints it to the
+// message log when no file is configured.
+func (g *Game) exportFumenL(p *Player) {
+	fumen, err := p.Matrix.Fumen()
+	if err != nil {
+		g.Logf(LogStandard, "* Failed to export matrix: %s", err)
+		return
+	}
+
+	if g.FumenPath == "" {
+		g.Logf(LogStandard, "* Fumen: %s", fumen)
+		return
+	}
+
+	err = SaveFumen(g.FumenPath, fumen)
+	if err != nil {
+		g.Logf(LogStandard, "* Failed to save fumen: %s", err)
+		return
+	}
+
+	g.Logf(LogStandard, "* Board saved to %s", g.FumenPath)
+}
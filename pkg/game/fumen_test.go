@@ -0,0 +1,36 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestSaveFumen(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "netris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := path.Join(dir, "config", FumenFile)
+	for _, fumen := range []string{"v115@bhJ8JeAgH", "v115@vhAAgH"} {
+		err = SaveFumen(p, fumen)
+		if err != nil {
+			t.Fatalf("failed to save fumen: %s", err)
+		}
+
+		buf, err := ioutil.ReadFile(p)
+		if err != nil {
+			t.Fatalf("failed to read fumen: %s", err)
+		}
+
+		expected := fumen + "\n" + FumenURL + fumen + "\n"
+		if string(buf) != expected {
+			t.Errorf("failed to save fumen: expected %q, got %q", expected, buf)
+		}
+	}
+}
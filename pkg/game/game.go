@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -16,10 +17,22 @@ import (
 
 const (
 	UpdateDuration = 850 * time.Millisecond
+	TickDuration   = 10 * time.Millisecond // Time between updates of the local matrix
 	IdleStart      = 5 * time.Second
 	IdleTimeout    = 1 * time.Minute
 )
 
+const (
+	DefaultNextPieces = 1
+	MaxNextPieces     = 6
+)
+
+const (
+	DefaultRank = 4
+	MinRank     = 1
+	MaxRank     = 5
+)
+
 const (
 	LogStandard = iota
 	LogDebug
@@ -56,13 +69,33 @@ type Game struct {
 	logger   chan string
 	LogLevel int
 
-	Rank  int
+	Rank  int   // Largest rank
+	Ranks []int // Ranks of minos played
 	Minos []mino.Mino
 	Seed  int64
 
 	FallTime   time.Duration
 	SpeedLimit int
 
+	NextPieces int // Number of upcoming pieces previewed
+
+	Rules     mino.Rules
+	Targeting Targeting
+
+	FumenPath string // Path to exported board file, boards are logged when blank
+
+	Puzzle      *mino.Puzzle // Puzzle played by the local player
+	LocalPuzzle bool         // Local game is played as a puzzle, which is retried by the player
+
+	Mode        Mode          // Mode played in local games
+	ModeGoal    int           // Lines to clear in sprint, seconds to play in ultra, starting level in marathon or garbage rows to clear in dig
+	Result      *ModeResult   // Result of the local player once the mode has ended
+	Records     []*ModeResult // Best results of the mode and goal, once the mode has ended
+	NewRecord   bool          // Result is the best result of the mode and goal
+	RecordsPath string        // Path to records file, records are not saved when blank
+
+	digAdded int // Garbage rows added in dig mode
+
 	sentPing time.Time
 	sync.Mutex
 }
@@ -76,6 +109,7 @@ func NewGame(rank int, out func(GameCommandInterface), logger chan string, draw
 	g := &Game{
 		Name:       "netris",
 		Rank:       rank,
+		Ranks:      []int{rank},
 		Minos:      minos,
 		nextPlayer: 1,
 		Players:    make(map[int]*Player),
@@ -93,13 +127,59 @@ func NewGame(rank int, out func(GameCommandInterface), logger chan string, draw
 		}
 	}
 
-	g.FallTime = 850 * time.Millisecond
+	g.FallTime = mino.DefaultFallTime
+	g.NextPieces = DefaultNextPieces
 
 	go g.handleDropTerminatedPlayers()
 
 	return g, nil
 }
 
+// ValidRanks returns the supplied ranks sorted and without duplicates, skipping
+// any which are not playable. The default rank is returned when none remain.
+func ValidRanks(ranks []int) []int {
+	var (
+		valid []int
+		found = make(map[int]bool)
+	)
+	for _, rank := range ranks {
+		if rank < MinRank || rank > MaxRank || found[rank] {
+			continue
+		}
+
+		valid = append(valid, rank)
+		found[rank] = true
+	}
+	if len(valid) == 0 {
+		return []int{DefaultRank}
+	}
+
+	sort.Ints(valid)
+	return valid
+}
+
+// SetRanksL sets the ranks of minos played. Ranks must be set before any
+// players are added.
+func (g *Game) SetRanksL(ranks []int) error {
+	ranks = ValidRanks(ranks)
+
+	var minos []mino.Mino
+	for _, rank := range ranks {
+		m, err := mino.Generate(rank)
+		if err != nil {
+			return fmt.Errorf("failed to generate minos of rank %d: %s", rank, err)
+		}
+
+		minos = append(minos, m...)
+	}
+
+	g.Rank = ranks[len(ranks)-1]
+	g.Ranks = ranks
+	g.Minos = minos
+
+	return nil
+}
+
 func (g *Game) Log(level int, a ...interface{}) {
 	if g.logger == nil || level > g.LogLevel {
 		return
@@ -135,19 +215,19 @@ func (g *Game) AddPlayerL(p *Player) {
 
 	g.Players[p.Player] = p
 
-	// TODO Verify rank-2 is valid for all playable rank previews
-	p.Preview = mino.NewMatrix(g.Rank, g.Rank-2, 0, 1, g.Event, g.draw, mino.MatrixPreview)
-	p.Preview.PlayerName = p.Name
-
-	p.Matrix = mino.NewMatrix(10, 20, 4, 1, g.Event, g.draw, mino.MatrixStandard)
+	w, h := g.Rules.Size()
+	p.Matrix = mino.NewMatrix(w, h, 4, 1, g.Event, g.draw, mino.MatrixStandard)
 	p.Matrix.PlayerName = p.Name
+	p.Matrix.Rules = g.Rules
+
+	g.addPreviewsL(p)
 
 	if g.Started {
 		p.Matrix.SetGameOver()
 	}
 
 	if g.LocalPlayer == PlayerHost {
-		p.Write(&GameCommandJoinGame{PlayerID: p.Player})
+		p.Write(&GameCommandJoinGame{PlayerID: p.Player, Listing: ListedGame{Ranks: g.Ranks, Targeting: g.Targeting, Mode: g.Mode, ModeGoal: g.ModeGoal, Rules: g.Rules}})
 
 		var players = make(map[int]string)
 		for _, player := range g.Players {
@@ -235,6 +315,16 @@ func (g *Game) Start(seed int64) int64 {
 	return g.StartL(seed)
 }
 
+// nowL returns the current time of the local player's matrix, which may be
+// driven by a manual clock, or the system time when there is no local matrix.
+func (g *Game) nowL() time.Time {
+	if p, ok := g.Players[g.LocalPlayer]; ok && p.Matrix != nil && p.Matrix.Clock != nil {
+		return p.Matrix.Clock.Now()
+	}
+
+	return time.Now()
+}
+
 func (g *Game) StartL(seed int64) int64 {
 	restarting := g.Seed != 0
 
@@ -243,7 +333,7 @@ func (g *Game) StartL(seed int64) int64 {
 	}
 
 	g.Started = true
-	g.TimeStarted = time.Now()
+	g.TimeStarted = g.nowL()
 
 	if g.LocalPlayer == PlayerUnknown {
 		log.Fatal("failed to start game: player unknown")
@@ -254,16 +344,28 @@ func (g *Game) StartL(seed int64) int64 {
 	}
 	g.Seed = seed
 
-	for _, p := range g.Players {
-		bag, err := mino.NewBag(g.Seed, g.Minos, 10)
+	for playerID, p := range g.Players {
+		if g.Puzzle != nil && playerID == g.LocalPlayer {
+			err := g.loadPuzzleL(p)
+			if err != nil {
+				log.Fatalf("failed to start game: failed to load puzzle: %s", err)
+			}
+			continue
+		}
+
+		bag, err := mino.NewBag(g.Seed, g.Minos, p.Matrix.W, g.Rules.Randomizer)
 		if err != nil {
 			log.Fatalf("failed to start game: failed to create bag: %s", err)
 		}
 
-		p.Preview.AttachBag(bag)
+		for _, preview := range p.Previews {
+			preview.AttachBag(bag)
+		}
 		p.Matrix.AttachBag(bag)
 	}
 
+	g.startModeL()
+
 	// Take piece on host as well to give initial position for start of game
 	for playerID, p := range g.Players {
 		if !p.Matrix.TakePiece() {
@@ -287,9 +389,7 @@ func (g *Game) StartL(seed int64) int64 {
 		}
 	} else {
 		if !restarting {
-			go g.handleLowerPiece()
-
-			go g.Players[g.LocalPlayer].Matrix.HandleReceiveGarbage()
+			go g.handleTick()
 			go g.handleSendMatrix()
 		}
 	}
@@ -301,6 +401,113 @@ func (g *Game) StartL(seed int64) int64 {
 	return g.Seed
 }
 
+// SetPuzzle sets the puzzle played by the local player. The puzzle starts
+// immediately when the game has already started.
+func (g *Game) SetPuzzle(puzzle *mino.Puzzle) error {
+	g.Lock()
+	defer g.Unlock()
+
+	g.Puzzle = puzzle
+	if !g.Started {
+		return nil
+	}
+
+	return g.retryPuzzleL()
+}
+
+// loadPuzzleL places the puzzle on the player's matrix and deals its pieces.
+func (g *Game) loadPuzzleL(p *Player) error {
+	bag, err := g.Puzzle.NewBag(p.Matrix.W)
+	if err != nil {
+		return fmt.Errorf("failed to create bag: %s", err)
+	}
+
+	err = p.Matrix.LoadPuzzle(g.Puzzle)
+	if err != nil {
+		return err
+	}
+
+	for _, preview := range p.Previews {
+		preview.AttachBag(bag)
+	}
+	p.Matrix.AttachBag(bag)
+
+	if g.Puzzle.Name != "" {
+		g.Logf(LogStandard, "* Puzzle: %s - %s", g.Puzzle.Name, g.Puzzle.GoalString())
+	} else {
+		g.Logf(LogStandard, "* Puzzle: %s", g.Puzzle.GoalString())
+	}
+
+	return nil
+}
+
+// retryPuzzleL restarts the puzzle played by the local player.
+func (g *Game) retryPuzzleL() error {
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		return errors.New("player unknown")
+	}
+
+	p.Score = 0
+	for _, preview := range p.Previews {
+		preview.Reset()
+	}
+	p.Matrix.Reset()
+
+	err := g.loadPuzzleL(p)
+	if err != nil {
+		return err
+	}
+
+	if !p.Matrix.TakePiece() {
+		return errors.New("failed to take piece")
+	}
+
+	g.draw <- event.DrawAll
+
+	return nil
+}
+
+// SetNextPieces sets the number of upcoming pieces previewed.
+func (g *Game) SetNextPieces(n int) {
+	g.Lock()
+	defer g.Unlock()
+
+	if n < 1 {
+		n = 1
+	} else if n > MaxNextPieces {
+		n = MaxNextPieces
+	}
+	g.NextPieces = n
+
+	for _, p := range g.Players {
+		g.addPreviewsL(p)
+	}
+
+	g.draw <- event.DrawAll
+}
+
+func (g *Game) addPreviewsL(p *Player) {
+	p.Previews = make([]*mino.Matrix, g.NextPieces)
+	for i := range p.Previews {
+		p.Previews[i] = mino.NewMatrix(g.Rank, previewHeight(g.Rank), 0, 1, g.Event, g.draw, mino.MatrixPreview)
+		p.Previews[i].PlayerName = p.Name
+		p.Previews[i].AttachBag(p.Matrix.Bag)
+	}
+}
+
+// previewHeight returns the height needed to preview any mino of a rank.
+func previewHeight(rank int) int {
+	switch {
+	case rank <= 2:
+		return 1
+	case rank == 3:
+		return 2
+	default:
+		return rank - 2
+	}
+}
+
 func (g *Game) Reset() {
 	g.Lock()
 	defer g.Unlock()
@@ -316,14 +523,21 @@ func (g *Game) ResetL() {
 	g.TimeStarted = time.Time{}
 	g.setGameOverL(false)
 	g.sentGameOverMatrix = false
+	g.Result = nil
+	g.Records = nil
+	g.NewRecord = false
+	g.digAdded = 0
 
 	for _, p := range g.Players {
 		p.totalGarbageSent = 0
 		p.totalGarbageReceived = 0
 		p.pendingGarbage = 0
 		p.Score = 0
+		p.Target = PlayerUnknown
 
-		p.Preview.Reset()
+		for _, preview := range p.Previews {
+			preview.Reset()
+		}
 		p.Matrix.Reset()
 	}
 
@@ -362,6 +576,7 @@ func (g *Game) handleSendMatrix() {
 			continue
 		}
 
+		m.UpdatePendingGarbageWait()
 		matrixes[0] = m
 
 		g.out(&GameCommandUpdateMatrix{Matrixes: matrixes})
@@ -422,14 +637,20 @@ func (g *Game) handleDistributeMatrixes() {
 			g.setGameOverL(true)
 
 			if g.Local {
-				g.WriteMessage("Game over")
+				// Endless games restart automatically, while modes and
+				// puzzles are restarted by the player
+				if g.Mode == ModeEndless && !g.LocalPuzzle {
+					for _, p := range g.Players {
+						g.WriteMessage(fmt.Sprintf("Game over - Score: %d", p.Score))
+					}
 
-				go func() {
-					time.Sleep(3 * time.Second)
+					go func() {
+						time.Sleep(3 * time.Second)
 
-					g.Reset()
-					g.Start(0)
-				}()
+						g.Reset()
+						g.Start(0)
+					}()
+				}
 			} else {
 				winner := "Tie!"
 				var (
@@ -463,6 +684,21 @@ func (g *Game) handleDistributeMatrixes() {
 
 				g.WriteMessage(fmt.Sprintf("Winner: %s - Garbage sent/received: %s", winner, garbageMessage.String()))
 
+				sort.Slice(players, func(i, j int) bool {
+					return players[i].Score > players[j].Score
+				})
+
+				var scoreMessage strings.Builder
+				for i, p := range players {
+					if i > 0 {
+						scoreMessage.WriteString(", ")
+					}
+
+					scoreMessage.WriteString(fmt.Sprintf("%s %d", p.Name, p.Score))
+				}
+
+				g.WriteMessage(fmt.Sprintf("Score: %s", scoreMessage.String()))
+
 				if len(g.Players) < 2 {
 					g.WriteMessage("Game will start when there are at least two players")
 				}
@@ -489,11 +725,16 @@ func (g *Game) handleDistributeMatrixes() {
 			}
 		}
 
+		if g.Started && !g.gameOver {
+			g.retargetL()
+		}
+
 		matrixes = make(map[int]*mino.Matrix)
 		for playerID, player := range g.Players {
 			player.Matrix.PlayerName = player.Name
 			player.Matrix.GarbageSent = player.totalGarbageSent
 			player.Matrix.GarbageReceived = player.totalGarbageReceived
+			player.Matrix.UpdatePendingGarbageWait()
 
 			matrixes[playerID] = player.Matrix
 		}
@@ -601,6 +842,7 @@ func (g *Game) HandleReadCommands(in chan GameCommandInterface) {
 					}
 
 					g.Players[player].Matrix.Replace(m)
+					g.Players[player].Score = m.Score
 				}
 
 				g.draw <- event.DrawMultiplayerMatrixes
@@ -609,6 +851,12 @@ func (g *Game) HandleReadCommands(in chan GameCommandInterface) {
 			if p, ok := e.(*GameCommandReceiveGarbage); ok {
 				g.Players[g.LocalPlayer].Matrix.AddPendingGarbage(p.Lines)
 			}
+		case CommandSetTarget:
+			if p, ok := e.(*GameCommandSetTarget); ok {
+				g.Players[g.LocalPlayer].Target = p.Target
+
+				g.draw <- event.DrawMultiplayerMatrixes
+			}
 		case CommandGameOver:
 			if p, ok := e.(*GameCommandGameOver); ok {
 				if p.Winner != "" {
@@ -690,8 +938,18 @@ func (g *Game) handle() {
 			g.out(&GameCommandNickname{Nickname: ev.Nickname})
 		} else if ev, ok := e.(*event.SendGarbageEvent); ok {
 			g.out(&GameCommandSendGarbage{Lines: ev.Lines})
+		} else if ev, ok := e.(*event.PuzzleEvent); ok {
+			if ev.Solved {
+				g.Log(LogStandard, "* Puzzle solved! Press R to play again")
+			} else {
+				g.Log(LogStandard, "* Puzzle failed - Press R to retry")
+			}
+		} else if _, ok := e.(*event.PerfectClearEvent); ok {
+			g.out(&GameCommandPerfectClear{})
 		} else if ev, ok := e.(*event.ScoreEvent); ok {
-			g.Players[ev.Player].Score += ev.Score
+			if p, ok := g.Players[g.LocalPlayer]; ok {
+				p.Score += ev.Score
+			}
 
 			if ev.Message != "" {
 				g.Log(LogStandard, ev.Message)
@@ -701,43 +959,44 @@ func (g *Game) handle() {
 				g.Log(LogStandard, ev.Message)
 			}
 		} else {
-			log.Fatalf("unknown event type: %v", e)
+			g.Logf(LogDebug, "Ignored unknown event type: %T", e)
 		}
 	}
 }
 
-func (g *Game) handleLowerPiece() {
-	var (
-		ticker *time.Ticker
-	)
-
-	m := g.Players[g.LocalPlayer].Matrix
-
-	ticker = time.NewTicker(g.FallTime)
+// handleTick advances the local player's matrix every TickDuration.
+func (g *Game) handleTick() {
+	t := time.NewTicker(TickDuration)
 	for {
-		select {
-		case <-m.Move:
-			ticker.Stop()
-			ticker = time.NewTicker(g.FallTime)
-			continue
-		case <-ticker.C:
-			for {
-				select {
-				case <-m.Move:
-					continue
-				default:
-				}
-
-				break
-			}
-		}
+		<-t.C
 
 		g.Lock()
-		m.LowerPiece()
+		g.tickL()
 		g.Unlock()
 	}
 }
 
+// tickL advances the local player's matrix, increasing the level first when
+// gravity ramps over time.
+func (g *Game) tickL() {
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		return
+	}
+	m := p.Matrix
+
+	// End timed modes before advancing the matrix past the time limit
+	g.checkModeL()
+
+	if g.Rules.Gravity == mino.GravityTime && !g.TimeStarted.IsZero() {
+		m.SetLevel(1 + int(g.nowL().Sub(g.TimeStarted)/mino.GravityRampInterval))
+	}
+
+	m.Tick(g.FallTime)
+
+	g.checkModeL()
+}
+
 func (g *Game) processUpdateGame(gc *GameCommandUpdateGame) {
 	g.Lock()
 	defer g.Unlock()
@@ -778,19 +1037,26 @@ func (g *Game) ProcessActionL(a event.GameAction) {
 			return
 		}
 
+		// Actions taken after the time limit of a mode are ignored
+		g.checkModeL()
+
 		switch a {
 		case event.ActionRotateCCW:
 			p.Matrix.RotatePiece(1, 1)
 		case event.ActionRotateCW:
 			p.Matrix.RotatePiece(1, 0)
+		case event.ActionRotate180:
+			p.Matrix.RotatePiece(2, 0)
 		case event.ActionMoveLeft:
 			p.Matrix.MovePiece(-1, 0)
 		case event.ActionMoveRight:
 			p.Matrix.MovePiece(1, 0)
 		case event.ActionSoftDrop:
-			p.Matrix.MovePiece(0, -1)
+			p.Matrix.SoftDropPiece()
 		case event.ActionHardDrop:
 			p.Matrix.HardDropPiece()
+		case event.ActionHold:
+			p.Matrix.HoldPiece()
 		case event.ActionNick:
 			g.out(&GameCommandNickname{Nickname: Nickname(p.Name)})
 		case event.ActionPing:
@@ -798,7 +1064,33 @@ func (g *Game) ProcessActionL(a event.GameAction) {
 			g.out(&GameCommandPing{Message: fmt.Sprintf("m%d", g.sentPing.UnixNano())})
 		case event.ActionStats:
 			g.out(&GameCommandStats{})
+		case event.ActionTarget:
+			if g.Targeting != TargetManual {
+				g.Logf(LogStandard, "* Manual targeting is disabled - this game targets %s", strings.ToLower(g.Targeting.String()))
+				return
+			}
+
+			if target := g.nextTargetL(); target != PlayerUnknown {
+				g.out(&GameCommandSetTarget{Target: target})
+			}
+		case event.ActionRetry:
+			if g.Mode != ModeEndless {
+				g.out(&GameCommandStartGame{})
+				return
+			} else if g.Puzzle == nil {
+				g.Log(LogStandard, "* Retry is only available in puzzles and modes")
+				return
+			}
+
+			err := g.retryPuzzleL()
+			if err != nil {
+				g.Logf(LogStandard, "* Failed to retry puzzle: %s", err)
+			}
+		case event.ActionFumen:
+			g.exportFumenL(p)
 		}
+
+		g.checkModeL()
 	}
 }
 
@@ -0,0 +1,369 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+	"code.rocket9labs.com/tslocum/netris/pkg/mino"
+)
+
+// Mode is a single player game with a goal, played locally.
+type Mode int
+
+const (
+	ModeEndless  Mode = iota // Play until topping out
+	ModeSprint               // Clear a number of lines as quickly as possible
+	ModeUltra                // Score as many points as possible before time expires
+	ModeMarathon             // Survive from a starting level through MarathonEndLevel
+	ModeDig                  // Clear a number of garbage rows as quickly as possible
+)
+
+// Last level of marathon mode, which ends once the level is cleared
+const MarathonEndLevel = 15
+
+// Rows of garbage kept in the matrix during dig mode until the goal has been added
+const DigRows = 10
+
+// Goals which may be selected for each mode, the first being the default
+var ModeGoals = map[Mode][]int{
+	ModeEndless:  {0},
+	ModeSprint:   {40, 20, 100},
+	ModeUltra:    {120, 180, 300, 60},
+	ModeMarathon: {1, 5, 10},
+	ModeDig:      {10, 20, 40, 100},
+}
+
+func (m Mode) String() string {
+	switch m {
+	case ModeEndless:
+		return "Endless"
+	case ModeSprint:
+		return "Sprint"
+	case ModeUltra:
+		return "Ultra"
+	case ModeMarathon:
+		return "Marathon"
+	case ModeDig:
+		return "Dig"
+	default:
+		return "Unknown"
+	}
+}
+
+func (m Mode) Valid() bool {
+	return m >= ModeEndless && m <= ModeDig
+}
+
+// ValidGoal returns the supplied goal, or the default goal of the mode when
+// the goal is not playable.
+func (m Mode) ValidGoal(goal int) int {
+	switch m {
+	case ModeSprint, ModeDig:
+		if goal < 1 || goal > 999 {
+			return ModeGoals[m][0]
+		}
+		return goal
+	case ModeUltra:
+		if goal < 10 || goal > 3600 {
+			return ModeGoals[m][0]
+		}
+		return goal
+	case ModeMarathon:
+		if goal < 1 || goal > MarathonEndLevel {
+			return ModeGoals[m][0]
+		}
+		return goal
+	default:
+		return 0
+	}
+}
+
+// GoalString returns a description of a goal of the mode.
+func (m Mode) GoalString(goal int) string {
+	switch m {
+	case ModeSprint:
+		return fmt.Sprintf("%d Lines", goal)
+	case ModeUltra:
+		if goal%60 != 0 {
+			return fmt.Sprintf("%d Seconds", goal)
+		} else if goal == 60 {
+			return "1 Minute"
+		}
+		return fmt.Sprintf("%d Minutes", goal/60)
+	case ModeMarathon:
+		return fmt.Sprintf("Level %d", goal)
+	case ModeDig:
+		return fmt.Sprintf("%d Rows", goal)
+	default:
+		return "None"
+	}
+}
+
+// ScoreRanked returns whether results of the mode are ranked by score rather
+// than by time. Scores are kept even when the player tops out.
+func (m Mode) ScoreRanked() bool {
+	return m == ModeUltra || m == ModeMarathon
+}
+
+// TimeLimit returns the time limit of a goal of the mode, or zero when the mode
+// is not timed.
+func (m Mode) TimeLimit(goal int) time.Duration {
+	if m != ModeUltra {
+		return 0
+	}
+
+	return time.Duration(goal) * time.Second
+}
+
+// ModeResult is the outcome of a mode played by the local player.
+type ModeResult struct {
+	Mode      Mode          `json:"mode"`
+	Goal      int           `json:"goal"`
+	Completed bool          `json:"completed"`
+	Time      time.Duration `json:"time"`
+	Pieces    int           `json:"pieces"`
+	Lines     int           `json:"lines"`
+	Garbage   int           `json:"garbage,omitempty"`
+	Score     int           `json:"score"`
+	Level     int           `json:"level"`
+	Date      time.Time     `json:"date"`
+}
+
+// PPS returns the pieces placed per second.
+func (r *ModeResult) PPS() float64 {
+	if r.Time <= 0 {
+		return 0
+	}
+
+	return float64(r.Pieces) / r.Time.Seconds()
+}
+
+// better returns whether the result ranks above another result of the same
+// mode and goal.
+func (r *ModeResult) better(other *ModeResult) bool {
+	if r.Completed != other.Completed {
+		return r.Completed
+	}
+
+	if r.Mode.ScoreRanked() {
+		return r.Score > other.Score
+	}
+	return r.Time < other.Time
+}
+
+// Summary returns a description of the result.
+func (r *ModeResult) Summary() string {
+	var status string
+	if r.Completed {
+		status = "complete"
+	} else if r.Mode.ScoreRanked() {
+		status = "ended"
+	} else {
+		status = "failed"
+	}
+
+	var summary string
+	switch r.Mode {
+	case ModeUltra:
+		summary = fmt.Sprintf("%d points in %s", r.Score, FormatDuration(r.Time))
+	case ModeMarathon:
+		summary = fmt.Sprintf("%d points at level %d in %s", r.Score, r.Level, FormatDuration(r.Time))
+	case ModeDig:
+		summary = fmt.Sprintf("%d/%d garbage rows in %s", r.Garbage, r.Goal, FormatDuration(r.Time))
+	default:
+		summary = fmt.Sprintf("%d lines in %s", r.Lines, FormatDuration(r.Time))
+	}
+
+	return fmt.Sprintf("%s %s - %s (%.2f PPS)", r.Mode, status, summary, r.PPS())
+}
+
+// FormatDuration formats a duration as minutes, seconds and milliseconds.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	d = d.Truncate(time.Millisecond)
+	return fmt.Sprintf("%d:%02d.%03d", d/time.Minute, (d%time.Minute)/time.Second, (d%time.Second)/time.Millisecond)
+}
+
+// ModeTimeL returns the time the local player has played the current mode,
+// or the final time once the mode has ended.
+func (g *Game) ModeTimeL() time.Duration {
+	if g.Result != nil {
+		return g.Result.Time
+	}
+
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil || !g.Started || g.TimeStarted.IsZero() {
+		return 0
+	}
+
+	return g.nowL().Sub(g.TimeStarted)
+}
+
+// startModeL prepares the matrix of the local player to play the mode.
+func (g *Game) startModeL() {
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		return
+	}
+
+	switch g.Mode {
+	case ModeMarathon:
+		p.Matrix.SetStartLevel(g.ModeGoal)
+	case ModeDig:
+		g.digAdded = 0
+		if !g.fillDigL(p.Matrix) {
+			p.Matrix.SetGameOver()
+		}
+	}
+}
+
+// fillDigL adds garbage until DigRows rows of garbage remain in the matrix, or
+// every row of the goal has been added. False is returned when the garbage
+// could not be added without topping out.
+func (g *Game) fillDigL(m *mino.Matrix) bool {
+	lines := DigRows - m.GarbageRows()
+	if remaining := g.ModeGoal - g.digAdded; lines > remaining {
+		lines = remaining
+	}
+	if lines <= 0 {
+		return true
+	}
+
+	g.digAdded += lines
+	return m.AddGarbage(lines)
+}
+
+// DigClearedL returns the number of garbage rows the local player has cleared
+// in dig mode.
+func (g *Game) DigClearedL() int {
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		return 0
+	}
+
+	return g.digAdded - p.Matrix.GarbageRows()
+}
+
+// checkModeL ends the mode played by the local player once its goal is
+// reached or the player has topped out.
+func (g *Game) checkModeL() {
+	if g.Mode == ModeEndless || g.Result != nil || !g.Started || g.LocalPlayer == PlayerHost {
+		return
+	}
+
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok || p.Matrix == nil {
+		return
+	}
+	m := p.Matrix
+
+	now := g.nowL()
+
+	var completed bool
+	switch g.Mode {
+	case ModeSprint:
+		completed = m.LinesCleared >= g.ModeGoal
+	case ModeUltra:
+		// End exactly at the time limit, even when checked late
+		limit := g.TimeStarted.Add(g.Mode.TimeLimit(g.ModeGoal))
+		if !now.Before(limit) {
+			completed = true
+			now = limit
+		}
+	case ModeMarathon:
+		completed = m.Level > MarathonEndLevel
+	case ModeDig:
+		if !m.GameOver && !g.fillDigL(m) {
+			m.SetGameOver()
+		}
+
+		completed = !m.GameOver && g.digAdded >= g.ModeGoal && m.GarbageRows() == 0
+	}
+	if !completed && !m.GameOver {
+		return
+	}
+
+	g.finishModeL(p, completed, now)
+}
+
+// finishModeL records the result of the mode played by the local player,
+// which ended at the specified time.
+func (g *Game) finishModeL(p *Player, completed bool, now time.Time) {
+	m := p.Matrix
+
+	if completed {
+		m.Finish()
+	}
+
+	g.Result = &ModeResult{
+		Mode:      g.Mode,
+		Goal:      g.ModeGoal,
+		Completed: completed,
+		Time:      now.Sub(g.TimeStarted),
+		Pieces:    m.Pieces,
+		Lines:     m.LinesCleared,
+		Score:     m.Score,
+		Level:     m.Level,
+		Date:      time.Now(),
+	}
+	switch g.Mode {
+	case ModeMarathon:
+		// Report the last level played rather than the level reached
+		if g.Result.Level > MarathonEndLevel {
+			g.Result.Level = MarathonEndLevel
+		}
+	case ModeDig:
+		g.Result.Garbage = g.DigClearedL()
+	}
+
+	g.Records, g.NewRecord = nil, false
+	if g.RecordsPath != "" {
+		records, err := LoadRecords(g.RecordsPath)
+		if err != nil {
+			g.Logf(LogStandard, "* Failed to load records: %s", err)
+		} else {
+			if records.Add(g.Result) {
+				err = records.Save(g.RecordsPath)
+				if err != nil {
+					g.Logf(LogStandard, "* Failed to save records: %s", err)
+				}
+			}
+
+			g.Records = records[recordsKey(g.Mode, g.ModeGoal)]
+			g.NewRecord = records.Best(g.Mode, g.ModeGoal) == g.Result
+		}
+	}
+
+	g.Log(LogStandard, "* "+g.Result.Summary())
+	if g.NewRecord {
+		g.Log(LogStandard, "* New personal best!")
+	} else if rank := g.RankL(); rank > 0 {
+		g.Logf(LogStandard, "* Ranked #%d of your best results", rank)
+	}
+	g.Log(LogStandard, "* Press R to play again")
+
+	g.draw <- event.DrawAll
+}
+
+// RankL returns the rank of the result of the local player among the records
+// of the mode and goal, or 0 when the result was not recorded.
+func (g *Game) RankL() int {
+	for i, r := range g.Records {
+		if r == g.Result {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// validateModeL restricts the mode and goal of the game to playable values.
+func (g *Game) validateModeL() {
+	if !g.Mode.Valid() {
+		g.Mode = ModeEndless
+	}
+	g.ModeGoal = g.Mode.ValidGoal(g.ModeGoal)
+}
@@ -0,0 +1,133 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+	"code.rocket9labs.com/tslocum/netris/pkg/mino"
+)
+
+func TestModeTime(t *testing.T) {
+	t.Parallel()
+
+	draw := make(chan event.DrawObject, 100)
+	g, err := NewGame(4, func(GameCommandInterface) {}, make(chan string, 100), draw)
+	if err != nil {
+		t.Fatalf("failed to create game: %s", err)
+	}
+	g.Mode, g.ModeGoal = ModeSprint, 40
+	g.LocalPlayer = 1
+
+	clock := mino.NewManualClock(time.Unix(0, 0))
+
+	p := NewPlayer("Player", nil)
+	p.Matrix = mino.NewMatrix(10, 20, 4, 1, make(chan interface{}, 100), draw, mino.MatrixStandard)
+	p.Matrix.SetClock(clock)
+	g.Players[g.LocalPlayer] = p
+
+	g.Lock()
+	g.StartL(1)
+	g.Unlock()
+
+	clock.Advance(30 * time.Second)
+
+	g.Lock()
+	elapsed := g.ModeTimeL()
+	g.Unlock()
+	if elapsed != 30*time.Second {
+		t.Fatalf("failed to measure mode time: expected %s, got %s", 30*time.Second, elapsed)
+	}
+
+	clock.Advance(15 * time.Second)
+	p.Matrix.LinesCleared = 40
+
+	g.Lock()
+	defer g.Unlock()
+
+	g.checkModeL()
+	if g.Result == nil || !g.Result.Completed {
+		t.Fatal("failed to end mode upon reaching goal")
+	} else if g.Result.Time != 45*time.Second {
+		t.Errorf("failed to end mode upon reaching goal: expected time %s, got %s", 45*time.Second, g.Result.Time)
+	}
+}
+
+func TestModeTimeLimit(t *testing.T) {
+	t.Parallel()
+
+	draw := make(chan event.DrawObject, 100)
+	g, err := NewGame(4, func(GameCommandInterface) {}, make(chan string, 100), draw)
+	if err != nil {
+		t.Fatalf("failed to create game: %s", err)
+	}
+	g.Mode, g.ModeGoal = ModeUltra, 60
+	g.LocalPlayer = 1
+
+	clock := mino.NewManualClock(time.Unix(0, 0))
+
+	p := NewPlayer("Player", nil)
+	p.Matrix = mino.NewMatrix(10, 20, 4, 1, make(chan interface{}, 100), draw, mino.MatrixStandard)
+	p.Matrix.SetClock(clock)
+	g.Players[g.LocalPlayer] = p
+
+	g.Lock()
+	defer g.Unlock()
+
+	g.StartL(1)
+
+	clock.Advance(30 * time.Second)
+
+	g.checkModeL()
+	if g.Result != nil {
+		t.Fatal("failed to play until time limit: mode ended")
+	}
+
+	clock.Advance(45 * time.Second)
+
+	g.checkModeL()
+	if g.Result == nil || !g.Result.Completed {
+		t.Fatal("failed to end mode at time limit")
+	} else if g.Result.Time != 60*time.Second {
+		t.Errorf("failed to end mode at time limit: expected time %s, got %s", 60*time.Second, g.Result.Time)
+	}
+}
+
+func TestMarathon(t *testing.T) {
+	t.Parallel()
+
+	draw := make(chan event.DrawObject, 100)
+	g, err := NewGame(4, func(GameCommandInterface) {}, make(chan string, 100), draw)
+	if err != nil {
+		t.Fatalf("failed to create game: %s", err)
+	}
+	g.Mode, g.ModeGoal = ModeMarathon, ModeMarathon.ValidGoal(MarathonEndLevel)
+	g.LocalPlayer = 1
+
+	if g.ModeGoal != MarathonEndLevel {
+		t.Fatalf("failed to start marathon at level %d: got level %d", MarathonEndLevel, g.ModeGoal)
+	}
+
+	p := NewPlayer("Player", nil)
+	p.Matrix = mino.NewMatrix(10, 20, 4, 1, make(chan interface{}, 100), draw, mino.MatrixStandard)
+	g.Players[g.LocalPlayer] = p
+
+	g.Lock()
+	defer g.Unlock()
+
+	g.StartL(1)
+
+	g.checkModeL()
+	if g.Result != nil {
+		t.Fatalf("failed to play level %d: mode ended", MarathonEndLevel)
+	}
+
+	p.Matrix.Level = MarathonEndLevel + 1
+
+	g.checkModeL()
+	if g.Result == nil || !g.Result.Completed {
+		t.Fatalf("failed to end mode after clearing level %d", MarathonEndLevel)
+	} else if g.Result.Level != MarathonEndLevel {
+		t.Errorf("failed to report last level played: expected %d, got %d", MarathonEndLevel, g.Result.Level)
+	}
+}
@@ -25,11 +25,12 @@ type Player struct {
 
 	*Conn
 
-	Score   int
-	Preview *mino.Matrix
-	Matrix  *mino.Matrix
-	Moved   time.Time     // Time of last piece move
-	Idle    time.Duration // Time spent idling
+	Score    int
+	Previews []*mino.Matrix // Upcoming pieces
+	Matrix   *mino.Matrix
+	Moved    time.Time     // Time of last piece move
+	Idle     time.Duration // Time spent idling
+	Target   int           // Player receiving garbage
 
 	pendingGarbage       int
 	totalGarbageSent     int
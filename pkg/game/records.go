@@ -0,0 +1,107 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// RecordsFile is the name of the file which stores records, saved alongside
+// the client configuration.
+const RecordsFile = "records.json"
+
+// Records are the best results of the local player, keyed by mode and goal.
+type Records map[string][]*ModeResult
+
+func recordsKey(mode Mode, goal int) string {
+	return fmt.Sprintf("%s-%d", strings.ToLower(mode.String()), goal)
+}
+
+// recordsKept returns the number of results kept for each goal of a mode.
+func recordsKept(mode Mode) int {
+	switch mode {
+	case ModeSprint, ModeDig:
+		return 1
+	case ModeUltra, ModeMarathon:
+		return 10
+	default:
+		return 0
+	}
+}
+
+// LoadRecords reads records from the specified file. No records are returned
+// when the file does not exist.
+func LoadRecords(p string) (Records, error) {
+	r := make(Records)
+
+	buf, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return r, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(buf, &r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", p, err)
+	}
+
+	return r, nil
+}
+
+// Save writes records to the specified file.
+func (r Records) Save(p string) error {
+	buf, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(p), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(p, buf, 0600)
+}
+
+// Best returns the best result of a mode and goal, or nil when there is none.
+func (r Records) Best(mode Mode, goal int) *ModeResult {
+	results := r[recordsKey(mode, goal)]
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results[0]
+}
+
+// Add records a result and returns whether it was kept. Results ranked by time
+// are only recorded when completed, while scores are recorded however the mode
+// ended.
+func (r Records) Add(result *ModeResult) bool {
+	kept := recordsKept(result.Mode)
+	if kept == 0 || (!result.Completed && !result.Mode.ScoreRanked()) {
+		return false
+	}
+
+	key := recordsKey(result.Mode, result.Goal)
+
+	results := append(r[key], result)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].better(results[j])
+	})
+	if len(results) > kept {
+		results = results[:kept]
+	}
+	r[key] = results
+
+	for _, res := range results {
+		if res == result {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestRecordsAdd(t *testing.T) {
+	t.Parallel()
+
+	sprint := func(completed bool, seconds int) *ModeResult {
+		return &ModeResult{Mode: ModeSprint, Goal: 40, Completed: completed, Time: time.Duration(seconds) * time.Second}
+	}
+	ultra := func(completed bool, score int) *ModeResult {
+		return &ModeResult{Mode: ModeUltra, Goal: 120, Completed: completed, Score: score}
+	}
+	marathon := func(completed bool, score int) *ModeResult {
+		return &ModeResult{Mode: ModeMarathon, Goal: 1, Completed: completed, Score: score}
+	}
+
+	testCases := []struct {
+		existing []*ModeResult
+		result   *ModeResult
+		kept     bool
+		expected []int // Indexes of existing results and -1 for the result, in order
+	}{
+		// Sprint keeps the fastest completed result
+		{nil, sprint(true, 60), true, []int{-1}},
+		{[]*ModeResult{sprint(true, 60)}, sprint(true, 50), true, []int{-1}},
+		{[]*ModeResult{sprint(true, 60)}, sprint(true, 70), false, []int{0}},
+		{[]*ModeResult{sprint(true, 60)}, sprint(false, 10), false, []int{0}},
+		{nil, sprint(false, 10), false, nil},
+
+		// Ultra keeps the highest scores, including runs which topped out
+		{nil, ultra(false, 800), true, []int{-1}},
+		{[]*ModeResult{ultra(true, 500), ultra(false, 300)}, ultra(false, 400), true, []int{0, -1, 1}},
+		{[]*ModeResult{ultra(false, 900)}, ultra(true, 200), true, []int{-1, 0}},
+
+		// Marathon keeps the highest scores, ranking completed results first
+		{[]*ModeResult{marathon(false, 300), marathon(false, 100)}, marathon(false, 200), true, []int{0, -1, 1}},
+		{[]*ModeResult{marathon(false, 300)}, marathon(true, 100), true, []int{-1, 0}},
+		{[]*ModeResult{marathon(true, 300)}, marathon(false, 500), true, []int{0, -1}},
+	}
+
+	for i, tc := range testCases {
+		r := make(Records)
+		key := recordsKey(tc.result.Mode, tc.result.Goal)
+		r[key] = append([]*ModeResult(nil), tc.existing...)
+
+		if kept := r.Add(tc.result); kept != tc.kept {
+			t.Errorf("case %d: failed to add result: expected kept %v, got %v", i, tc.kept, kept)
+			continue
+		}
+
+		results := r[key]
+		if len(results) != len(tc.expected) {
+			t.Errorf("case %d: failed to add result: expected %d results, got %d", i, len(tc.expected), len(results))
+			continue
+		}
+		for j, index := range tc.expected {
+			expected := tc.result
+			if index >= 0 {
+				expected = tc.existing[index]
+			}
+
+			if results[j] != expected {
+				t.Errorf("case %d: failed to sort results: unexpected result at rank %d", i, j+1)
+			}
+		}
+	}
+}
+
+func TestRecordsTrim(t *testing.T) {
+	t.Parallel()
+
+	r := make(Records)
+	for score := 1; score <= recordsKept(ModeUltra)+5; score++ {
+		r.Add(&ModeResult{Mode: ModeUltra, Goal: 120, Score: score * 100})
+	}
+
+	results := r[recordsKey(ModeUltra, 120)]
+	if len(results) != recordsKept(ModeUltra) {
+		t.Fatalf("failed to trim results: expected %d results, got %d", recordsKept(ModeUltra), len(results))
+	}
+	for i, result := range results {
+		if expected := (recordsKept(ModeUltra) + 5 - i) * 100; result.Score != expected {
+			t.Errorf("failed to trim results: expected score %d at rank %d, got %d", expected, i+1, result.Score)
+		}
+	}
+
+	if r.Add(&ModeResult{Mode: ModeUltra, Goal: 120, Score: 100}) {
+		t.Error("failed to trim results: kept a score lower than every kept score")
+	}
+	if r.Best(ModeUltra, 120) != results[0] {
+		t.Error("failed to get best result")
+	} else if r.Best(ModeUltra, 180) != nil {
+		t.Error("failed to get best result: expected no result of another goal")
+	}
+}
+
+func TestRecordsSave(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "netris")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := path.Join(dir, "records", RecordsFile)
+
+	r, err := LoadRecords(p)
+	if err != nil {
+		t.Fatalf("failed to load missing records: %s", err)
+	} else if len(r) != 0 {
+		t.Fatalf("failed to load missing records: expected no records, got %d", len(r))
+	}
+
+	result := &ModeResult{Mode: ModeSprint, Goal: 20, Completed: true, Time: 42 * time.Second, Pieces: 50, Lines: 20}
+	r.Add(result)
+
+	err = r.Save(p)
+	if err != nil {
+		t.Fatalf("failed to save records: %s", err)
+	}
+
+	r, err = LoadRecords(p)
+	if err != nil {
+		t.Fatalf("failed to load records: %s", err)
+	}
+
+	best := r.Best(ModeSprint, 20)
+	if best == nil || best.Time != result.Time || best.Pieces != result.Pieces || !best.Completed {
+		t.Errorf("failed to load records: expected %+v, got %+v", result, best)
+	}
+}
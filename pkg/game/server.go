@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"code.rocket9labs.com/tslocum/netris/pkg/event"
+	"code.rocket9labs.com/tslocum/netris/pkg/mino"
 )
 
 const (
@@ -122,7 +123,7 @@ func (s *Server) NewGame() (*Game, error) {
 		}
 	}()
 
-	g, err := NewGame(4, nil, logger, draw)
+	g, err := NewGame(DefaultRank, nil, logger, draw)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +191,22 @@ func (s *Server) FindGame(p *Player, gameID int, newGame ListedGame) *Game {
 			g.SpeedLimit = 999
 		}
 
+		err = g.SetRanksL(newGame.Ranks)
+		if err != nil {
+			log.Fatalf("failed to create custom game: %s", err)
+		}
+
+		g.Targeting = newGame.Targeting
+		if !g.Targeting.Valid() {
+			g.Targeting = TargetEven
+		}
+
+		g.Rules = newGame.Rules
+		g.Rules.Validate()
+		if w, _ := g.Rules.Size(); w < g.Rank {
+			g.Rules.Width = g.Rank
+		}
+
 		g.Unlock()
 	} else if gameID > 0 {
 		// Join a game by its ID
@@ -234,6 +251,13 @@ func (s *Server) FindGame(p *Player, gameID int, newGame ListedGame) *Game {
 		}
 
 		g.Local = true
+		g.Rules.Gravity = mino.GravityLevel
+
+		g.Mode = newGame.Mode
+		g.ModeGoal = newGame.ModeGoal
+		g.validateModeL()
+
+		g.LocalPuzzle = newGame.Puzzle
 	}
 
 	if g == nil {
@@ -291,7 +315,7 @@ func (s *Server) handleNewPlayer(pl *Player) {
 						continue
 					}
 
-					gl = append(gl, &ListedGame{ID: g.ID, Name: g.Name, Players: len(g.Players), MaxPlayers: g.MaxPlayers, SpeedLimit: g.SpeedLimit})
+					gl = append(gl, &ListedGame{ID: g.ID, Name: g.Name, Players: len(g.Players), MaxPlayers: g.MaxPlayers, SpeedLimit: g.SpeedLimit, Ranks: g.Ranks, Targeting: g.Targeting, Rules: g.Rules})
 					g.Unlock()
 				}
 				s.Unlock()
@@ -392,6 +416,7 @@ func (s *Server) handleGameCommands(pl *Player, g *Game) {
 			if pl, ok := g.Players[p.SourcePlayer]; ok {
 				for _, m := range p.Matrixes {
 					pl.Matrix.Replace(m)
+					pl.Score = m.Score
 
 					if g.SpeedLimit > 0 && m.Speed > g.SpeedLimit+5 && time.Since(g.TimeStarted) > 7*time.Second {
 						pl.Matrix.SetGameOver()
@@ -411,27 +436,36 @@ func (s *Server) handleGameCommands(pl *Player, g *Game) {
 		case *GameCommandGameOver:
 			g.Players[p.SourcePlayer].Matrix.SetGameOver()
 
-			g.WriteMessage(fmt.Sprintf("%s was knocked out", g.Players[p.SourcePlayer].Name))
+			if !g.Local {
+				g.WriteMessage(fmt.Sprintf("%s was knocked out", g.Players[p.SourcePlayer].Name))
+			}
 			g.WriteAllL(&GameCommandGameOver{Player: p.SourcePlayer})
 		case *GameCommandSendGarbage:
-			leastGarbagePlayer := -1
-			leastGarbage := -1
-			for playerID, player := range g.Players {
-				if playerID == p.SourcePlayer || player.Matrix.GameOver {
-					continue
-				}
+			target := g.attackTargetL(p.SourcePlayer)
+			if target != PlayerUnknown {
+				g.Players[target].totalGarbageReceived += p.Lines
+				g.Players[target].pendingGarbage += p.Lines
 
-				if leastGarbage == -1 || player.totalGarbageReceived < leastGarbage {
-					leastGarbagePlayer = playerID
-					leastGarbage = player.totalGarbageReceived
+				g.Players[p.SourcePlayer].totalGarbageSent += p.Lines
+			}
+			g.retargetL()
+		case *GameCommandSetTarget:
+			if pl, ok := g.Players[p.SourcePlayer]; ok && g.Targeting == TargetManual {
+				if t, ok := g.Players[p.Target]; ok && p.Target != p.SourcePlayer && !t.Matrix.GameOver {
+					g.SetTargetL(p.SourcePlayer, p.Target)
+				} else {
+					pl.Write(&GameCommandMessage{Message: "Failed to set target - Invalid player"})
 				}
 			}
-
-			if leastGarbagePlayer != -1 {
-				g.Players[leastGarbagePlayer].totalGarbageReceived += p.Lines
-				g.Players[leastGarbagePlayer].pendingGarbage += p.Lines
-
-				g.Players[p.SourcePlayer].totalGarbageSent += p.Lines
+		case *GameCommandStartGame:
+			// Modes of local games are restarted by the player
+			if g.Local && g.Mode != ModeEndless {
+				g.ResetL()
+				g.StartL(0)
+			}
+		case *GameCommandPerfectClear:
+			if pl, ok := g.Players[p.SourcePlayer]; ok {
+				g.WriteMessage(fmt.Sprintf("%s performed a perfect clear", pl.Name))
 			}
 		case *GameCommandStats:
 			go func(p *Player) {
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Targeting selects which opponent receives a player's garbage.
+type Targeting int
+
+const (
+	TargetEven      Targeting = iota // Opponent who has received the least garbage
+	TargetRandom                     // Random opponent, selected again after each attack
+	TargetAttackers                  // Opponent targeting the player
+	TargetKO                         // Opponent with the highest stack
+	TargetManual                     // Opponent selected by the player
+)
+
+func (t Targeting) String() string {
+	switch t {
+	case TargetEven:
+		return "Even"
+	case TargetRandom:
+		return "Random"
+	case TargetAttackers:
+		return "Attackers"
+	case TargetKO:
+		return "KO"
+	case TargetManual:
+		return "Manual"
+	default:
+		return "Unknown"
+	}
+}
+
+// Valid returns whether the targeting mode is known.
+func (t Targeting) Valid() bool {
+	return t >= TargetEven && t <= TargetManual
+}
+
+// opponentsL returns the IDs of the players which may be targeted by the
+// specified player, in ascending order.
+func (g *Game) opponentsL(playerID int) []int {
+	var opponents []int
+	for id, p := range g.Players {
+		if id == playerID || p.Matrix == nil || p.Matrix.GameOver {
+			continue
+		}
+
+		opponents = append(opponents, id)
+	}
+	sort.Ints(opponents)
+
+	return opponents
+}
+
+// leastGarbageL returns the opponent which has received the least garbage.
+func (g *Game) leastGarbageL(opponents []int) int {
+	target := PlayerUnknown
+	for _, id := range opponents {
+		if target == PlayerUnknown || g.Players[id].totalGarbageReceived < g.Players[target].totalGarbageReceived {
+			target = id
+		}
+	}
+
+	return target
+}
+
+// selectTargetL returns the opponent the specified player should target.
+func (g *Game) selectTargetL(playerID int) int {
+	p, ok := g.Players[playerID]
+	if !ok {
+		return PlayerUnknown
+	}
+
+	opponents := g.opponentsL(playerID)
+	if len(opponents) == 0 {
+		return PlayerUnknown
+	}
+
+	switch g.Targeting {
+	case TargetRandom, TargetManual:
+		for _, id := range opponents {
+			if id == p.Target {
+				return id
+			}
+		}
+
+		if g.Targeting == TargetRandom {
+			return opponents[rand.Intn(len(opponents))]
+		}
+	case TargetAttackers:
+		var attackers []int
+		for _, id := range opponents {
+			if g.Players[id].Target == playerID {
+				attackers = append(attackers, id)
+			}
+		}
+
+		if len(attackers) > 0 {
+			return g.leastGarbageL(attackers)
+		}
+	case TargetKO:
+		target := PlayerUnknown
+		highest := -1
+		for _, id := range opponents {
+			if h := g.Players[id].Matrix.StackHeight(); h > highest {
+				target = id
+				highest = h
+			}
+		}
+
+		return target
+	}
+
+	return g.leastGarbageL(opponents)
+}
+
+// attackTargetL returns the opponent which receives an attack by the specified
+// player. Random targeting selects a new target after each attack.
+func (g *Game) attackTargetL(playerID int) int {
+	target := g.selectTargetL(playerID)
+
+	if p, ok := g.Players[playerID]; ok && g.Targeting == TargetRandom {
+		p.Target = PlayerUnknown
+	}
+
+	return target
+}
+
+// SetTargetL sets the opponent targeted by the specified player and notifies
+// the player when the target changes.
+func (g *Game) SetTargetL(playerID int, target int) {
+	p, ok := g.Players[playerID]
+	if !ok || p.Target == target {
+		return
+	}
+
+	p.Target = target
+	p.Write(&GameCommandSetTarget{Target: target})
+}
+
+// retargetL updates the opponent targeted by each player.
+func (g *Game) retargetL() {
+	for playerID := range g.Players {
+		g.SetTargetL(playerID, g.selectTargetL(playerID))
+	}
+}
+
+// nextTargetL returns the opponent following the local player's current
+// target, wrapping around to the first opponent.
+func (g *Game) nextTargetL() int {
+	p, ok := g.Players[g.LocalPlayer]
+	if !ok {
+		return PlayerUnknown
+	}
+
+	opponents := g.opponentsL(g.LocalPlayer)
+	if len(opponents) == 0 {
+		return PlayerUnknown
+	}
+
+	for _, id := range opponents {
+		if id > p.Target {
+			return id
+		}
+	}
+
+	return opponents[0]
+}
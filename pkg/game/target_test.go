@@ -0,0 +1,147 @@
+package game
+
+import (
+	"testing"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/mino"
+)
+
+// targetPlayer is the state of a player in a targeting test.
+type targetPlayer struct {
+	target   int  // Player targeted
+	received int  // Garbage received
+	height   int  // Stack height
+	out      bool // Player has topped out
+}
+
+func newTargetGame(targeting Targeting, players map[int]targetPlayer) *Game {
+	g := &Game{Targeting: targeting, LocalPlayer: 1, Players: make(map[int]*Player)}
+	for id, tp := range players {
+		m := mino.NewMatrix(10, 20, 4, 1, nil, nil, mino.MatrixStandard)
+		for y := 0; y < tp.height; y++ {
+			m.SetBlock(0, y, mino.BlockGarbage, false)
+		}
+		m.GameOver = tp.out
+
+		g.Players[id] = &Player{Matrix: m, Target: tp.target, totalGarbageReceived: tp.received}
+	}
+
+	return g
+}
+
+func TestSelectTarget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		targeting Targeting
+		players   map[int]targetPlayer
+		expected  []int // Any of these targets are expected
+	}{
+		// Even
+		{TargetEven, map[int]targetPlayer{1: {}, 2: {received: 3}, 3: {received: 1}}, []int{3}},
+		{TargetEven, map[int]targetPlayer{1: {}, 2: {received: 3}, 3: {received: 1, out: true}}, []int{2}},
+		{TargetEven, map[int]targetPlayer{1: {}, 2: {received: 3}}, []int{2}},
+		{TargetEven, map[int]targetPlayer{1: {}, 2: {out: true}}, []int{PlayerUnknown}},
+
+		// Random
+		{TargetRandom, map[int]targetPlayer{1: {target: 3}, 2: {}, 3: {}, 4: {}}, []int{3}},
+		{TargetRandom, map[int]targetPlayer{1: {target: 3}, 2: {}, 3: {out: true}, 4: {}}, []int{2, 4}},
+		{TargetRandom, map[int]targetPlayer{1: {}, 2: {}, 3: {}}, []int{2, 3}},
+		{TargetRandom, map[int]targetPlayer{1: {}, 2: {}}, []int{2}},
+		{TargetRandom, map[int]targetPlayer{1: {target: 2}, 2: {out: true}}, []int{PlayerUnknown}},
+
+		// Attackers
+		{TargetAttackers, map[int]targetPlayer{1: {}, 2: {target: 4}, 3: {target: 1, received: 2}, 4: {}}, []int{3}},
+		{TargetAttackers, map[int]targetPlayer{1: {}, 2: {target: 1, received: 5}, 3: {target: 1, received: 2}, 4: {}}, []int{3}},
+		{TargetAttackers, map[int]targetPlayer{1: {}, 2: {target: 3, received: 2}, 3: {target: 2, received: 1}}, []int{3}},
+		{TargetAttackers, map[int]targetPlayer{1: {}, 2: {target: 1, out: true}, 3: {target: 4, received: 2}, 4: {received: 1}}, []int{4}},
+		{TargetAttackers, map[int]targetPlayer{1: {}, 2: {target: 3}}, []int{2}},
+
+		// KO
+		{TargetKO, map[int]targetPlayer{1: {height: 10}, 2: {height: 3}, 3: {height: 7}}, []int{3}},
+		{TargetKO, map[int]targetPlayer{1: {}, 2: {height: 3}, 3: {height: 7, out: true}}, []int{2}},
+		{TargetKO, map[int]targetPlayer{1: {}, 2: {}}, []int{2}},
+
+		// Manual
+		{TargetManual, map[int]targetPlayer{1: {target: 2}, 2: {}, 3: {}}, []int{2}},
+		{TargetManual, map[int]targetPlayer{1: {target: 2}, 2: {out: true}, 3: {received: 4}, 4: {received: 1}}, []int{4}},
+		{TargetManual, map[int]targetPlayer{1: {}, 2: {}}, []int{2}},
+	}
+
+	for i, tc := range testCases {
+		g := newTargetGame(tc.targeting, tc.players)
+
+		target := g.selectTargetL(1)
+
+		var ok bool
+		for _, expected := range tc.expected {
+			if target == expected {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("case %d: failed to select %s target: expected one of %v, got %d", i, tc.targeting, tc.expected, target)
+		}
+	}
+
+	g := newTargetGame(TargetEven, map[int]targetPlayer{1: {}, 2: {}})
+	if target := g.selectTargetL(3); target != PlayerUnknown {
+		t.Errorf("failed to select target of unknown player: expected %d, got %d", PlayerUnknown, target)
+	}
+}
+
+func TestAttackTarget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		targeting Targeting
+		players   map[int]targetPlayer
+		expected  int // Target attacked
+		retarget  int // Target after the attack
+	}{
+		{TargetEven, map[int]targetPlayer{1: {target: 3}, 2: {received: 2}, 3: {}}, 3, 3},
+		{TargetRandom, map[int]targetPlayer{1: {target: 3}, 2: {}, 3: {}}, 3, PlayerUnknown},
+		{TargetRandom, map[int]targetPlayer{1: {}, 2: {}}, 2, PlayerUnknown},
+		{TargetRandom, map[int]targetPlayer{1: {target: 2}, 2: {out: true}, 3: {}}, 3, PlayerUnknown},
+		{TargetAttackers, map[int]targetPlayer{1: {target: 2}, 2: {}, 3: {target: 1}}, 3, 2},
+		{TargetKO, map[int]targetPlayer{1: {target: 2}, 2: {height: 1}, 3: {height: 4}}, 3, 2},
+		{TargetManual, map[int]targetPlayer{1: {target: 2}, 2: {}, 3: {}}, 2, 2},
+	}
+
+	for i, tc := range testCases {
+		g := newTargetGame(tc.targeting, tc.players)
+
+		if target := g.attackTargetL(1); target != tc.expected {
+			t.Errorf("case %d: failed to select %s attack target: expected %d, got %d", i, tc.targeting, tc.expected, target)
+		} else if retarget := g.Players[1].Target; retarget != tc.retarget {
+			t.Errorf("case %d: failed to update %s target after attack: expected %d, got %d", i, tc.targeting, tc.retarget, retarget)
+		}
+	}
+}
+
+func TestNextTarget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		players  map[int]targetPlayer
+		expected int
+	}{
+		{map[int]targetPlayer{1: {}, 2: {}, 3: {}, 4: {}}, 2},
+		{map[int]targetPlayer{1: {target: 2}, 2: {}, 3: {}, 4: {}}, 3},
+		{map[int]targetPlayer{1: {target: 4}, 2: {}, 3: {}, 4: {}}, 2},
+		{map[int]targetPlayer{1: {target: 2}, 2: {}, 3: {out: true}, 4: {}}, 4},
+		{map[int]targetPlayer{1: {target: 4}, 2: {}, 3: {}, 4: {out: true}}, 2},
+		{map[int]targetPlayer{1: {target: 2}, 2: {}}, 2},
+		{map[int]targetPlayer{1: {target: 2}, 2: {out: true}}, PlayerUnknown},
+		{map[int]targetPlayer{1: {}}, PlayerUnknown},
+	}
+
+	for i, tc := range testCases {
+		g := newTargetGame(TargetManual, tc.players)
+
+		if target := g.nextTargetL(); target != tc.expected {
+			t.Errorf("case %d: failed to select next target: expected %d, got %d", i, tc.expected, target)
+		}
+	}
+}
@@ -1,62 +1,108 @@
 package mino
 
 import (
+	"errors"
 	"math/rand"
 	"sync"
 )
 
 type Bag struct {
-	Minos    []Mino
 	Original []Mino
 
-	minoRandomizer    *rand.Rand
+	minoRandomizer    Randomizer
 	garbageRandomizer *rand.Rand
 
-	i     int
+	queue []Mino // Upcoming minos
+
 	width int
 	sync.Mutex
 }
 
-func NewBag(seed int64, minos []Mino, width int) (*Bag, error) {
+func NewBag(seed int64, minos []Mino, width int, randomizer RandomizerType) (*Bag, error) {
+	if len(minos) == 0 {
+		return nil, errors.New("no minos supplied")
+	}
+
 	minoSource := rand.NewSource(seed)
 	garbageSource := rand.NewSource(seed)
-	b := &Bag{Original: minos, minoRandomizer: rand.New(minoSource), garbageRandomizer: rand.New(garbageSource), width: width}
+	b := &Bag{Original: minos, minoRandomizer: NewRandomizer(randomizer, minos, rand.New(minoSource)), garbageRandomizer: rand.New(garbageSource), width: width}
 
-	b.shuffle()
+	return b, nil
+}
+
+// NewSequenceBag returns a bag which deals the supplied minos in order. No
+// minos are dealt once the sequence is exhausted.
+func NewSequenceBag(minos []Mino, width int) (*Bag, error) {
+	if len(minos) == 0 {
+		return nil, errors.New("no minos supplied")
+	}
+
+	b := &Bag{Original: minos, minoRandomizer: &sequenceRandomizer{minos: minos}, garbageRandomizer: rand.New(rand.NewSource(1)), width: width}
 
 	return b, nil
 }
 
+// Take removes and returns the next mino, or nil when the bag is exhausted.
 func (b *Bag) Take() Mino {
 	b.Lock()
 	defer b.Unlock()
 
-	mino := b.Minos[b.i]
-	if b.i == len(b.Minos)-1 {
-		b.shuffle()
-
-		b.i = 0
-	} else {
-		b.i++
+	b.fill(1)
+	if len(b.queue) == 0 {
+		return nil
 	}
 
+	mino := b.queue[0]
+	b.queue = b.queue[1:]
+
 	return mino
 }
 
+// Next returns the next mino without taking it, or nil when the bag is
+// exhausted.
 func (b *Bag) Next() Mino {
 	b.Lock()
 	defer b.Unlock()
 
-	return b.Minos[b.i]
+	b.fill(1)
+	if len(b.queue) == 0 {
+		return nil
+	}
+
+	return b.queue[0]
 }
 
-func (b *Bag) shuffle() {
-	if b.Minos == nil {
-		b.Minos = make([]Mino, len(b.Original))
+// Peek returns the next n minos without taking them. Fewer minos are returned
+// when the bag is exhausted.
+func (b *Bag) Peek(n int) []Mino {
+	b.Lock()
+	defer b.Unlock()
+
+	if n <= 0 {
+		return nil
+	}
+
+	b.fill(n)
+	if n > len(b.queue) {
+		n = len(b.queue)
 	}
-	copy(b.Minos, b.Original)
 
-	b.minoRandomizer.Shuffle(len(b.Minos), func(i, j int) { b.Minos[i], b.Minos[j] = b.Minos[j], b.Minos[i] })
+	minos := make([]Mino, n)
+	copy(minos, b.queue)
+	return minos
+}
+
+// fill queues minos until at least n minos are queued, or the randomizer is
+// exhausted.
+func (b *Bag) fill(n int) {
+	for len(b.queue) < n {
+		mn := b.minoRandomizer.Next()
+		if mn == nil {
+			return
+		}
+
+		b.queue = append(b.queue, mn)
+	}
 }
 
 func (b *Bag) GarbageHole() int {
@@ -65,3 +111,11 @@ func (b *Bag) GarbageHole() int {
 
 	return b.garbageRandomizer.Intn(b.width)
 }
+
+// GarbageChance returns true with the specified percent chance.
+func (b *Bag) GarbageChance(percent int) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.garbageRandomizer.Intn(100) < percent
+}
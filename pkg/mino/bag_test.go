@@ -23,7 +23,7 @@ func TestBag(t *testing.T) {
 				t.Errorf("failed to generate minos for rank %d: unexpected number of minos generated", d.Rank)
 			}
 
-			b, err := NewBag(0, minos, 10)
+			b, err := NewBag(0, minos, 10, RandomizerBag)
 			if err != nil {
 				t.Errorf("failed to create bag for rank %d: %s", d.Rank, err)
 			}
@@ -48,3 +48,42 @@ func TestBag(t *testing.T) {
 		})
 	}
 }
+
+func TestBagPeek(t *testing.T) {
+	t.Parallel()
+
+	minos, err := Generate(4)
+	if err != nil {
+		t.Fatalf("failed to generate minos: %s", err)
+	}
+
+	b, err := NewBag(0, minos, 10, RandomizerBag)
+	if err != nil {
+		t.Fatalf("failed to create bag: %s", err)
+	}
+
+	for i := 0; i < len(minos)*2; i++ {
+		peeked := b.Peek(6)
+		if len(peeked) != 6 {
+			t.Fatalf("failed to peek minos: expected 6 minos, got %d", len(peeked))
+		}
+
+		if next := b.Next(); next.String() != peeked[0].String() {
+			t.Errorf("failed to peek minos: next mino %s does not match peeked mino %s", next, peeked[0])
+		}
+
+		for j, peekedMino := range peeked {
+			if j == 0 {
+				continue
+			}
+
+			if again := b.Peek(j + 1)[j]; again.String() != peekedMino.String() {
+				t.Errorf("failed to peek minos: mino %d changed from %s to %s", j, peekedMino, again)
+			}
+		}
+
+		if taken := b.Take(); taken.String() != peeked[0].String() {
+			t.Errorf("failed to peek minos: taken mino %s does not match peeked mino %s", taken, peeked[0])
+		}
+	}
+}
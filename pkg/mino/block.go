@@ -1,10 +1,14 @@
 package mino
 
-import "code.rocket9labs.com/tslocum/netris/pkg/event"
+import (
+	"sync"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+)
 
 // Dark color ghosts are 60% original overlaid #777777
 // Light color ghosts are 40% original overlaid #888888
-var Colors = make([][]byte, 16)
+var Colors = make([][]byte, BlockSolidExtra22+1)
 
 type Block int
 
@@ -18,13 +22,31 @@ func (b Block) Rune() rune {
 		return ' '
 	case BlockGhostJ, BlockGhostI, BlockGhostZ, BlockGhostO, BlockGhostT, BlockGhostS, BlockGhostL:
 		return '▓'
+	case BlockGhostExtra1, BlockGhostExtra2, BlockGhostExtra3, BlockGhostExtra4, BlockGhostExtra5, BlockGhostExtra6, BlockGhostExtra7, BlockGhostExtra8, BlockGhostExtra9, BlockGhostExtra10, BlockGhostExtra11,
+		BlockGhostExtra12, BlockGhostExtra13, BlockGhostExtra14, BlockGhostExtra15, BlockGhostExtra16, BlockGhostExtra17, BlockGhostExtra18, BlockGhostExtra19, BlockGhostExtra20, BlockGhostExtra21, BlockGhostExtra22:
+		return '▓'
 	case BlockGarbage, BlockSolidJ, BlockSolidI, BlockSolidZ, BlockSolidO, BlockSolidT, BlockSolidS, BlockSolidL:
 		return '█'
+	case BlockSolidExtra1, BlockSolidExtra2, BlockSolidExtra3, BlockSolidExtra4, BlockSolidExtra5, BlockSolidExtra6, BlockSolidExtra7, BlockSolidExtra8, BlockSolidExtra9, BlockSolidExtra10, BlockSolidExtra11,
+		BlockSolidExtra12, BlockSolidExtra13, BlockSolidExtra14, BlockSolidExtra15, BlockSolidExtra16, BlockSolidExtra17, BlockSolidExtra18, BlockSolidExtra19, BlockSolidExtra20, BlockSolidExtra21, BlockSolidExtra22:
+		return '█'
 	default:
 		return '?'
 	}
 }
 
+// Ghost returns the ghost block of a solid block.
+func (b Block) Ghost() Block {
+	switch {
+	case b >= BlockSolidJ && b <= BlockSolidL:
+		return b - (BlockSolidJ - BlockGhostJ)
+	case b >= BlockSolidExtra1 && b <= BlockSolidExtra22:
+		return b - (BlockSolidExtra1 - BlockGhostExtra1)
+	default:
+		return b
+	}
+}
+
 const (
 	BlockNone Block = iota
 	BlockGarbage
@@ -42,22 +64,142 @@ const (
 	BlockSolidT
 	BlockSolidS
 	BlockSolidL
+	BlockGhostExtra1
+	BlockGhostExtra2
+	BlockGhostExtra3
+	BlockGhostExtra4
+	BlockGhostExtra5
+	BlockGhostExtra6
+	BlockGhostExtra7
+	BlockGhostExtra8
+	BlockGhostExtra9
+	BlockGhostExtra10
+	BlockGhostExtra11
+	BlockGhostExtra12
+	BlockGhostExtra13
+	BlockGhostExtra14
+	BlockGhostExtra15
+	BlockGhostExtra16
+	BlockGhostExtra17
+	BlockGhostExtra18
+	BlockGhostExtra19
+	BlockGhostExtra20
+	BlockGhostExtra21
+	BlockGhostExtra22
+	BlockSolidExtra1
+	BlockSolidExtra2
+	BlockSolidExtra3
+	BlockSolidExtra4
+	BlockSolidExtra5
+	BlockSolidExtra6
+	BlockSolidExtra7
+	BlockSolidExtra8
+	BlockSolidExtra9
+	BlockSolidExtra10
+	BlockSolidExtra11
+	BlockSolidExtra12
+	BlockSolidExtra13
+	BlockSolidExtra14
+	BlockSolidExtra15
+	BlockSolidExtra16
+	BlockSolidExtra17
+	BlockSolidExtra18
+	BlockSolidExtra19
+	BlockSolidExtra20
+	BlockSolidExtra21
+	BlockSolidExtra22
 )
 
 var ColorToBlock = map[event.GameColor]Block{
-	event.GameColorI:       BlockSolidI,
-	event.GameColorO:       BlockSolidO,
-	event.GameColorT:       BlockSolidT,
-	event.GameColorJ:       BlockSolidJ,
-	event.GameColorL:       BlockSolidL,
-	event.GameColorS:       BlockSolidS,
-	event.GameColorZ:       BlockSolidZ,
-	event.GameColorIGhost:  BlockGhostI,
-	event.GameColorOGhost:  BlockGhostO,
-	event.GameColorTGhost:  BlockGhostT,
-	event.GameColorJGhost:  BlockGhostJ,
-	event.GameColorLGhost:  BlockGhostL,
-	event.GameColorSGhost:  BlockGhostS,
-	event.GameColorZGhost:  BlockGhostZ,
-	event.GameColorGarbage: BlockGarbage,
+	event.GameColorI:            BlockSolidI,
+	event.GameColorO:            BlockSolidO,
+	event.GameColorT:            BlockSolidT,
+	event.GameColorJ:            BlockSolidJ,
+	event.GameColorL:            BlockSolidL,
+	event.GameColorS:            BlockSolidS,
+	event.GameColorZ:            BlockSolidZ,
+	event.GameColorIGhost:       BlockGhostI,
+	event.GameColorOGhost:       BlockGhostO,
+	event.GameColorTGhost:       BlockGhostT,
+	event.GameColorJGhost:       BlockGhostJ,
+	event.GameColorLGhost:       BlockGhostL,
+	event.GameColorSGhost:       BlockGhostS,
+	event.GameColorZGhost:       BlockGhostZ,
+	event.GameColorExtra1:       BlockSolidExtra1,
+	event.GameColorExtra2:       BlockSolidExtra2,
+	event.GameColorExtra3:       BlockSolidExtra3,
+	event.GameColorExtra4:       BlockSolidExtra4,
+	event.GameColorExtra5:       BlockSolidExtra5,
+	event.GameColorExtra6:       BlockSolidExtra6,
+	event.GameColorExtra7:       BlockSolidExtra7,
+	event.GameColorExtra8:       BlockSolidExtra8,
+	event.GameColorExtra9:       BlockSolidExtra9,
+	event.GameColorExtra10:      BlockSolidExtra10,
+	event.GameColorExtra11:      BlockSolidExtra11,
+	event.GameColorExtra12:      BlockSolidExtra12,
+	event.GameColorExtra13:      BlockSolidExtra13,
+	event.GameColorExtra14:      BlockSolidExtra14,
+	event.GameColorExtra15:      BlockSolidExtra15,
+	event.GameColorExtra16:      BlockSolidExtra16,
+	event.GameColorExtra17:      BlockSolidExtra17,
+	event.GameColorExtra18:      BlockSolidExtra18,
+	event.GameColorExtra19:      BlockSolidExtra19,
+	event.GameColorExtra20:      BlockSolidExtra20,
+	event.GameColorExtra21:      BlockSolidExtra21,
+	event.GameColorExtra22:      BlockSolidExtra22,
+	event.GameColorExtra1Ghost:  BlockGhostExtra1,
+	event.GameColorExtra2Ghost:  BlockGhostExtra2,
+	event.GameColorExtra3Ghost:  BlockGhostExtra3,
+	event.GameColorExtra4Ghost:  BlockGhostExtra4,
+	event.GameColorExtra5Ghost:  BlockGhostExtra5,
+	event.GameColorExtra6Ghost:  BlockGhostExtra6,
+	event.GameColorExtra7Ghost:  BlockGhostExtra7,
+	event.GameColorExtra8Ghost:  BlockGhostExtra8,
+	event.GameColorExtra9Ghost:  BlockGhostExtra9,
+	event.GameColorExtra10Ghost: BlockGhostExtra10,
+	event.GameColorExtra11Ghost: BlockGhostExtra11,
+	event.GameColorExtra12Ghost: BlockGhostExtra12,
+	event.GameColorExtra13Ghost: BlockGhostExtra13,
+	event.GameColorExtra14Ghost: BlockGhostExtra14,
+	event.GameColorExtra15Ghost: BlockGhostExtra15,
+	event.GameColorExtra16Ghost: BlockGhostExtra16,
+	event.GameColorExtra17Ghost: BlockGhostExtra17,
+	event.GameColorExtra18Ghost: BlockGhostExtra18,
+	event.GameColorExtra19Ghost: BlockGhostExtra19,
+	event.GameColorExtra20Ghost: BlockGhostExtra20,
+	event.GameColorExtra21Ghost: BlockGhostExtra21,
+	event.GameColorExtra22Ghost: BlockGhostExtra22,
+	event.GameColorGarbage:      BlockGarbage,
+}
+
+var (
+	pieceBlockIndex = make(map[string]int)
+	pieceBlockRank  int
+	pieceBlockLock  sync.Mutex
+)
+
+// pieceBlock returns the solid block of a mino which is not a tetromino. Blocks
+// are assigned in order of rank, skipping tetrominoes, so that each mino up to
+// rank 5 is assigned a different block regardless of which ranks are played.
+func pieceBlock(m Mino) Block {
+	pieceBlockLock.Lock()
+	defer pieceBlockLock.Unlock()
+
+	for pieceBlockRank < len(m) {
+		pieceBlockRank++
+		if pieceBlockRank == 4 {
+			continue
+		}
+
+		minos, err := Generate(pieceBlockRank)
+		if err != nil {
+			continue
+		}
+		for _, mn := range minos {
+			pieceBlockIndex[mn.String()] = len(pieceBlockIndex)
+		}
+	}
+
+	const extraBlocks = BlockSolidExtra22 - BlockSolidExtra1 + 1
+	return BlockSolidExtra1 + Block(pieceBlockIndex[m.Canonical().String()])%extraBlocks
 }
@@ -0,0 +1,47 @@
+package mino
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to a matrix.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is the default clock, which reports the system time.
+var SystemClock Clock = systemClock{}
+
+// ManualClock is a clock which only advances when instructed to.
+type ManualClock struct {
+	t time.Time
+
+	sync.Mutex
+}
+
+// NewManualClock returns a clock which starts at the specified time.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.t
+}
+
+// Advance moves the clock forward by the specified duration.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.t = c.t.Add(d)
+}
@@ -0,0 +1,210 @@
+package mino
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+)
+
+const (
+	DefaultFrame     = time.Second / 60
+	engineEventQueue = 64
+)
+
+// Engine runs a single matrix synchronously, without the system clock. Time only passes when the engine is stepped, and the events emitted
+// by each call are returned instead of being sent to a game.
+type Engine struct {
+	Matrix   *Matrix
+	FallTime time.Duration // Time per row at level 1
+	Frame    time.Duration // Time per step
+
+	clock  *ManualClock
+	events chan interface{}
+}
+
+// NewEngine returns an engine which deals the specified minos in the order
+// determined by the seed and rules.
+func NewEngine(rules Rules, minos []Mino, seed int64) (*Engine, error) {
+	rules.Validate()
+
+	w, h := rules.Size()
+
+	bag, err := NewBag(seed, minos, w, rules.Randomizer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bag: %s", err)
+	}
+
+	e := &Engine{
+		FallTime: DefaultFallTime,
+		Frame:    DefaultFrame,
+		clock:    NewManualClock(time.Unix(0, 0)),
+		events:   make(chan interface{}, engineEventQueue),
+	}
+
+	e.Matrix = NewMatrix(w, h, 4, 1, e.events, nil, MatrixStandard)
+	e.Matrix.Rules = rules
+	e.Matrix.SetClock(e.clock)
+	e.Matrix.AttachBag(bag)
+
+	if !e.Matrix.TakePiece() {
+		return nil, errors.New("failed to take first piece")
+	}
+
+	return e, nil
+}
+
+// Apply performs an action and returns whether it succeeded, along with the
+// events it emitted.
+func (e *Engine) Apply(a event.GameAction) (bool, []interface{}) {
+	if e.GameOver() {
+		return false, nil
+	}
+
+	var ok bool
+	events := e.run(func() {
+		ok = e.apply(a)
+	})
+
+	return ok, events
+}
+
+func (e *Engine) apply(a event.GameAction) bool {
+	m := e.Matrix
+
+	var ok bool
+	switch a {
+	case event.ActionRotateCCW:
+		ok = m.RotatePiece(1, 1)
+	case event.ActionRotateCW:
+		ok = m.RotatePiece(1, 0)
+	case event.ActionRotate180:
+		ok = m.RotatePiece(2, 0)
+	case event.ActionMoveLeft:
+		ok = m.MovePiece(-1, 0)
+	case event.ActionMoveRight:
+		ok = m.MovePiece(1, 0)
+	case event.ActionSoftDrop:
+		ok = m.SoftDropPiece()
+	case event.ActionHardDrop:
+		m.HardDropPiece()
+		ok = true
+	case event.ActionHold:
+		ok = m.HoldPiece()
+	}
+
+	return ok
+}
+
+// Step advances the engine by the specified number of frames and returns the
+// events emitted.
+func (e *Engine) Step(frames int) []interface{} {
+	var events []interface{}
+	for i := 0; i < frames && !e.GameOver(); i++ {
+		events = append(events, e.run(func() {
+			e.clock.Advance(e.Frame)
+			e.Matrix.Tick(e.FallTime)
+		})...)
+	}
+
+	return events
+}
+
+// AddGarbage queues lines of garbage, which rise after GarbageDelay.
+func (e *Engine) AddGarbage(lines int) {
+	e.Matrix.AddPendingGarbage(lines)
+}
+
+// Now returns the time elapsed since the engine was created.
+func (e *Engine) Now() time.Duration {
+	return e.clock.Now().Sub(time.Unix(0, 0))
+}
+
+// Board returns a copy of the blocks of the matrix, excluding the active piece.
+// Use I to find the index of a block.
+func (e *Engine) Board() []Block {
+	m := e.Matrix
+	m.Lock()
+	defer m.Unlock()
+
+	board := make([]Block, len(m.M))
+	copy(board, m.M)
+	return board
+}
+
+// Piece returns the active piece. The piece must not be modified.
+func (e *Engine) Piece() *Piece {
+	m := e.Matrix
+	m.Lock()
+	defer m.Unlock()
+
+	return m.P
+}
+
+// Hold returns the held piece, or nil when no piece is held. The piece must not
+// be modified.
+func (e *Engine) Hold() *Piece {
+	m := e.Matrix
+	m.Lock()
+	defer m.Unlock()
+
+	return m.Hold
+}
+
+// Queue returns the next n minos to be dealt.
+func (e *Engine) Queue(n int) []Mino {
+	return e.Matrix.Bag.Peek(n)
+}
+
+// GameOver returns whether the game has ended.
+func (e *Engine) GameOver() bool {
+	m := e.Matrix
+	m.Lock()
+	defer m.Unlock()
+
+	return m.GameOver
+}
+
+// run calls f and returns the events emitted while it ran. Events are received
+// as they are sent, so the matrix never blocks however many events are emitted.
+// The matrix is ended when a game over event is emitted, as a game would.
+func (e *Engine) run(f func()) []interface{} {
+	var (
+		events    []interface{}
+		stop      = make(chan struct{})
+		collected = make(chan struct{})
+	)
+	go func() {
+		defer close(collected)
+
+		for {
+			select {
+			case ev := <-e.events:
+				events = append(events, ev)
+			case <-stop:
+				for {
+					select {
+					case ev := <-e.events:
+						events = append(events, ev)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	f()
+	close(stop)
+	<-collected
+
+	for _, ev := range events {
+		if _, ok := ev.(*event.GameOverEvent); ok {
+			e.Matrix.SetGameOver()
+			break
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,92 @@
+package mino
+
+import (
+	"testing"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+)
+
+func TestEngine(t *testing.T) {
+	t.Parallel()
+
+	minos, err := Generate(4)
+	if err != nil {
+		t.Fatalf("failed to generate minos: %s", err)
+	}
+
+	actions := []event.GameAction{event.ActionMoveLeft, event.ActionMoveLeft, event.ActionHardDrop, event.ActionRotateCW, event.ActionMoveRight, event.ActionHardDrop, event.ActionHold, event.ActionHardDrop}
+
+	var boards [][]Block
+	for i := 0; i < 2; i++ {
+		e, err := NewEngine(Rules{}, minos, 7)
+		if err != nil {
+			t.Fatalf("failed to create engine: %s", err)
+		}
+
+		if q := e.Queue(3); len(q) != 3 {
+			t.Fatalf("failed to peek queue: expected 3 minos, got %d", len(q))
+		}
+
+		y := e.Piece().Y
+		e.Step(int(e.FallTime/e.Frame) + 1)
+		if e.Piece().Y != y-1 {
+			t.Fatalf("failed to lower piece: expected row %d, got %d", y-1, e.Piece().Y)
+		}
+
+		var scored bool
+		for _, a := range actions {
+			_, events := e.Apply(a)
+			for _, ev := range events {
+				if _, ok := ev.(*event.ScoreEvent); ok {
+					scored = true
+				}
+			}
+		}
+		if !scored {
+			t.Error("failed to return score events")
+		}
+		if e.Hold() == nil {
+			t.Error("failed to hold piece")
+		}
+
+		if ok, _ := e.Apply(event.ActionPing); ok {
+			t.Error("failed to reject unsupported action")
+		}
+
+		boards = append(boards, e.Board())
+	}
+
+	for i := range boards[0] {
+		if boards[0][i] != boards[1][i] {
+			t.Fatalf("failed to run deterministically: block %d differs", i)
+		}
+	}
+}
+
+func TestEngineEvents(t *testing.T) {
+	t.Parallel()
+
+	minos, err := Generate(4)
+	if err != nil {
+		t.Fatalf("failed to generate minos: %s", err)
+	}
+
+	e, err := NewEngine(Rules{}, minos, 7)
+	if err != nil {
+		t.Fatalf("failed to create engine: %s", err)
+	}
+
+	const sent = engineEventQueue * 4
+	events := e.run(func() {
+		for i := 0; i < sent; i++ {
+			e.Matrix.Event <- &event.ScoreEvent{Score: i}
+		}
+		e.Matrix.Event <- &event.GameOverEvent{}
+	})
+	if len(events) != sent+1 {
+		t.Fatalf("failed to receive events: expected %d, got %d", sent+1, len(events))
+	}
+	if !e.GameOver() {
+		t.Error("failed to end game after game over event")
+	}
+}
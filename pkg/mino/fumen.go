@@ -0,0 +1,266 @@
+package mino
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Fumen is the board sharing format of the fumen editor. Version 115 boards
+// are 10 blocks wide and 23 rows high, with an additional garbage row beneath
+// the floor, which is ignored.
+const (
+	fumenPrefix = "v115@"
+	fumenTable  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	fumenWidth  = 10
+	fumenHeight = 24
+	fumenBlocks = fumenWidth * fumenHeight
+
+	// Action of a page without a piece, with the guideline colors flag set as
+	// on the first page of boards shared by the fumen editor
+	fumenActionEmpty = 4 * fumenBlocks * 32
+)
+
+// Fumen block values
+const (
+	fumenEmpty = iota
+	fumenI
+	fumenL
+	fumenO
+	fumenZ
+	fumenT
+	fumenJ
+	fumenS
+	fumenGray
+)
+
+func fumenBlock(v int) Block {
+	switch v {
+	case fumenI:
+		return BlockSolidI
+	case fumenL:
+		return BlockSolidL
+	case fumenO:
+		return BlockSolidO
+	case fumenZ:
+		return BlockSolidZ
+	case fumenT:
+		return BlockSolidT
+	case fumenJ:
+		return BlockSolidJ
+	case fumenS:
+		return BlockSolidS
+	case fumenGray:
+		return BlockGarbage
+	default:
+		return BlockNone
+	}
+}
+
+func fumenValue(b Block) int {
+	switch b {
+	case BlockNone:
+		return fumenEmpty
+	case BlockSolidI, BlockGhostI:
+		return fumenI
+	case BlockSolidL, BlockGhostL:
+		return fumenL
+	case BlockSolidO, BlockGhostO:
+		return fumenO
+	case BlockSolidZ, BlockGhostZ:
+		return fumenZ
+	case BlockSolidT, BlockGhostT:
+		return fumenT
+	case BlockSolidJ, BlockGhostJ:
+		return fumenJ
+	case BlockSolidS, BlockGhostS:
+		return fumenS
+	default:
+		return fumenGray
+	}
+}
+
+// DecodeFumen returns the blocks of the first page of a fumen, which may be
+// supplied as a URL. Blocks are indexed by I with a width of 10, starting from
+// the bottom row.
+func DecodeFumen(fumen string) ([]Block, error) {
+	i := strings.Index(fumen, fumenPrefix)
+	if i == -1 {
+		return nil, errors.New("unsupported version")
+	}
+	data := strings.ReplaceAll(fumen[i+len(fumenPrefix):], "?", "")
+	if i := strings.IndexAny(data, "&#"); i != -1 {
+		data = data[:i]
+	}
+
+	poll := func(digits int) (int, error) {
+		if len(data) < digits {
+			return 0, errors.New("unexpected end of data")
+		}
+
+		var v int
+		for i := digits - 1; i >= 0; i-- {
+			d := strings.IndexByte(fumenTable, data[i])
+			if d == -1 {
+				return 0, fmt.Errorf("invalid character %q", data[i])
+			}
+
+			v = v*len(fumenTable) + d
+		}
+		data = data[digits:]
+
+		return v, nil
+	}
+
+	field := make([]int, fumenBlocks)
+	for n := 0; n < fumenBlocks; {
+		v, err := poll(2)
+		if err != nil {
+			return nil, err
+		}
+
+		value := v/fumenBlocks - 8
+		count := v%fumenBlocks + 1
+		if n+count > fumenBlocks {
+			return nil, errors.New("field exceeds board size")
+		} else if value < fumenEmpty || value > fumenGray {
+			return nil, fmt.Errorf("invalid block value %d", value)
+		}
+
+		for j := n; j < n+count; j++ {
+			field[j] = value
+		}
+		n += count
+
+		if value == fumenEmpty && count == fumenBlocks {
+			_, err = poll(1) // Following pages with the same field
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The piece placed by the first page is not part of its field
+	_, err := poll(3)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]Block, fumenWidth*(fumenHeight-1))
+	for j, v := range field {
+		y := fumenHeight - 2 - j/fumenWidth
+		if y < 0 {
+			continue // Garbage row
+		}
+
+		blocks[I(j%fumenWidth, y, fumenWidth)] = fumenBlock(v)
+	}
+
+	return blocks, nil
+}
+
+// EncodeFumen returns a fumen of the supplied blocks, indexed by I with a
+// width of 10, starting from the bottom row.
+func EncodeFumen(blocks []Block) (string, error) {
+	if len(blocks)%fumenWidth != 0 {
+		return "", errors.New("board is not 10 blocks wide")
+	}
+
+	var b strings.Builder
+	push := func(v int, digits int) {
+		for i := 0; i < digits; i++ {
+			b.WriteByte(fumenTable[v%len(fumenTable)])
+			v /= len(fumenTable)
+		}
+	}
+
+	field := make([]int, fumenBlocks)
+	for i, block := range blocks {
+		if block == BlockNone {
+			continue
+		}
+
+		y := i / fumenWidth
+		if y > fumenHeight-2 {
+			return "", fmt.Errorf("board exceeds %d rows", fumenHeight-1)
+		}
+
+		field[I(i%fumenWidth, fumenHeight-2-y, fumenWidth)] = fumenValue(block)
+	}
+
+	var runs int
+	for n := 0; n < fumenBlocks; {
+		count := 1
+		for n+count < fumenBlocks && field[n+count] == field[n] {
+			count++
+		}
+
+		push((field[n]+8)*fumenBlocks+count-1, 2)
+		runs++
+		n += count
+	}
+	if runs == 1 && field[0] == fumenEmpty {
+		push(0, 1) // Following pages with the same field
+	}
+
+	push(fumenActionEmpty, 3)
+
+	// Long fumens are split with question marks, as by the fumen editor
+	data := b.String()
+	if len(data) <= 42 {
+		return fumenPrefix + data, nil
+	}
+
+	chunks := []string{data[:42]}
+	for data = data[42:]; len(data) > 47; data = data[47:] {
+		chunks = append(chunks, data[:47])
+	}
+	if data != "" {
+		chunks = append(chunks, data)
+	}
+
+	return fumenPrefix + strings.Join(chunks, "?"), nil
+}
+
+// Fumen returns a fumen of the blocks of the matrix.
+func (m *Matrix) Fumen() (string, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.W != fumenWidth {
+		return "", errors.New("matrix is not 10 blocks wide")
+	}
+
+	return EncodeFumen(m.M)
+}
+
+// LoadFumen replaces the blocks of the matrix with the first page of a fumen.
+func (m *Matrix) LoadFumen(fumen string) error {
+	blocks, err := DecodeFumen(fumen)
+	if err != nil {
+		return fmt.Errorf("failed to decode fumen: %s", err)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if m.W != fumenWidth {
+		return errors.New("matrix is not 10 blocks wide")
+	}
+	for i := len(m.M); i < len(blocks); i++ {
+		if blocks[i] != BlockNone {
+			return errors.New("fumen exceeds matrix height")
+		}
+	}
+
+	for i := range m.M {
+		m.M[i] = BlockNone
+		if i < len(blocks) {
+			m.M[i] = blocks[i]
+		}
+	}
+
+	m.Draw()
+
+	return nil
+}
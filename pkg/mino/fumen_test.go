@@ -0,0 +1,59 @@
+package mino
+
+import "testing"
+
+func TestFumen(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fumen, err := m.Fumen()
+	if err != nil {
+		t.Fatalf("failed to encode empty matrix: %s", err)
+	} else if fumen != "v115@vhAAgH" {
+		t.Errorf("failed to encode empty matrix: got %s", fumen)
+	}
+
+	blocks, err := DecodeFumen("https://fumen.zui.jp/?v115@bhJ8JeAgH")
+	if err != nil {
+		t.Fatalf("failed to decode fumen: %s", err)
+	}
+	for i, b := range blocks {
+		if expected := i < fumenWidth; (b == BlockGarbage) != expected {
+			t.Fatalf("failed to decode fumen: unexpected block %d at %d", b, i)
+		}
+	}
+
+	m.AddTestBlocks()
+	m.SetBlock(0, 10, BlockSolidI, false)
+
+	fumen, err = m.Fumen()
+	if err != nil {
+		t.Fatalf("failed to encode matrix: %s", err)
+	}
+
+	o, err := NewTestMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = o.LoadFumen(fumen)
+	if err != nil {
+		t.Fatalf("failed to load fumen %s: %s", fumen, err)
+	}
+
+	for i := range m.M {
+		if o.M[i] != m.M[i] {
+			t.Fatalf("failed to load fumen %s: block %d differs: expected %d, got %d", fumen, i, m.M[i], o.M[i])
+		}
+	}
+
+	for _, invalid := range []string{"", "v110@vhAAgH", "v115@vh", "v115@!!AAgH"} {
+		if _, err := DecodeFumen(invalid); err == nil {
+			t.Errorf("failed to reject invalid fumen %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package mino
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultFallTime     = 850 * time.Millisecond // Time per row at level 1
+	LinesPerLevel       = 10
+	GravityRampInterval = 30 * time.Second // Time per level when gravity increases over time
+	Gravity20GLevel     = 20               // Level at which pieces drop instantly
+)
+
+// FallTime returns the time a piece takes to fall one row at the specified
+// level, starting from the base fall time at level 1.
+func FallTime(base time.Duration, level int) time.Duration {
+	if level <= 1 {
+		return base
+	}
+
+	l := float64(level - 1)
+	fallTime := time.Duration(float64(base) * math.Pow(0.8-(l*0.007), l))
+	if fallTime < time.Millisecond {
+		fallTime = time.Millisecond
+	}
+
+	return fallTime
+}
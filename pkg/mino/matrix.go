@@ -13,8 +13,9 @@ import (
 )
 
 const (
-	GarbageDelay  = 1500 * time.Millisecond // 1.5 seconds
-	ComboBaseTime = 2.4                     // Seconds
+	GarbageDelay    = 1500 * time.Millisecond // 1.5 seconds
+	GarbageInterval = 500 * time.Millisecond  // Time between rising garbage lines
+	ComboBaseTime   = 2.4                     // Seconds
 )
 
 type MatrixType int
@@ -35,29 +36,53 @@ type Matrix struct {
 
 	Bag        *Bag `json:"-"`
 	P          *Piece
+	Hold       *Piece `json:"hp,omitempty"` // Held piece
+	HoldUsed   bool   `json:"hu,omitempty"` // Held during the current turn
 	PlayerName string `json:"pn,omitempty"`
 
-	Type MatrixType `json:"ty,omitempty"`
+	Type  MatrixType `json:"ty,omitempty"`
+	Rules Rules      `json:"-"`
 
 	Event chan<- interface{} `json:"-"`
-	Move  chan int           `json:"-"`
+	Clock Clock              `json:"-"`
 	draw  chan event.DrawObject
 
-	Combo              int       `json:"mc,omitempty"`
-	ComboStart         time.Time `json:"-"`
-	ComboEnd           time.Time `json:"-"`
-	PendingGarbage     int       `json:"-"`
-	PendingGarbageTime time.Time `json:"-"`
+	Combo              int           `json:"mc,omitempty"`
+	ComboStart         time.Time     `json:"-"`
+	ComboEnd           time.Time     `json:"-"`
+	PendingGarbage     int           `json:"pg,omitempty"`
+	PendingGarbageTime time.Time     `json:"-"`
+	PendingGarbageWait time.Duration `json:"pw,omitempty"` // Time until pending garbage lands, as of the last update
 
 	LinesCleared    int `json:"lc,omitempty"`
+	Pieces          int `json:"pp,omitempty"` // Pieces placed
 	GarbageSent     int `json:"gs,omitempty"`
 	GarbageReceived int `json:"gr,omitempty"`
 	Speed           int `json:"sp,omitempty"`
+	Score           int `json:"sc,omitempty"`
+	Level           int `json:"lv,omitempty"`
+	StartLevel      int `json:"-"` // Level at the start of the game
+	PerfectClears   int `json:"pc,omitempty"`
+	BackToBack      int `json:"b2b,omitempty"` // Consecutive difficult line clears
+
+	scoreReported int // Score included in score events
+
+	garbageAttacks  []int // Pending lines of each attack, oldest first
+	garbageReceived int   // Lines received of the oldest pending attack
+	garbageHole     int   // Column of the last garbage hole
+	garbageHoleSet  bool  // Whether a garbage hole has been placed
 
 	GameOver bool `json:"go,omitempty"`
 
+	Puzzle       *Puzzle `json:"-"`
+	puzzlePlaced int     // Pieces placed in the puzzle
+	puzzleLines  int     // Lines cleared in the puzzle
+
 	lands []time.Time
 
+	lastFall    time.Time // Time the active piece last fell
+	lastGarbage time.Time // Time pending garbage was last checked
+
 	sync.Mutex `json:"-"`
 }
 
@@ -78,19 +103,75 @@ func NewMatrix(w int, h int, b int, players int, event chan<- interface{}, draw
 		M:     make([]Block, w*(h+b)),
 		O:     make([]Block, w*(h+b)),
 		Event: event,
-		Move:  make(chan int, 10),
+		Clock: SystemClock,
 		draw:  draw,
+		Level: 1,
 	}
 
 	return &m
 }
 
-func (m *Matrix) HandleReceiveGarbage() {
-	t := time.NewTicker(500 * time.Millisecond)
-	for {
-		<-t.C
+func (m *Matrix) now() time.Time {
+	if m.Clock == nil {
+		return time.Now()
+	}
+
+	return m.Clock.Now()
+}
+
+// SetClock sets the clock which provides the current time to the matrix and
+// restarts its timers.
+func (m *Matrix) SetClock(c Clock) {
+	m.Lock()
+	defer m.Unlock()
 
-		m.ReceiveGarbage()
+	m.Clock = c
+	m.lastFall = time.Time{}
+	m.lastGarbage = time.Time{}
+}
+
+// Tick advances the matrix to the current time of its clock. The active piece
+// falls one row for each fall time elapsed at the current level, starting from
+// the specified base fall time. Landed pieces lock once their lock delay
+// expires, and pending garbage rises one line every GarbageInterval.
+func (m *Matrix) Tick(base time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.GameOver || m.P == nil {
+		return
+	}
+
+	now := m.now()
+	if m.lastFall.IsZero() {
+		m.lastFall = now
+	}
+	if m.lastGarbage.IsZero() {
+		m.lastGarbage = now
+	}
+
+	fallTime := base
+	if m.Rules.Gravity != GravityStatic {
+		fallTime = FallTime(base, m.Level)
+	}
+
+	for !m.GameOver && now.Sub(m.lastFall) >= fallTime {
+		next := m.lastFall.Add(fallTime)
+		if !m.canAddAt(m.P, Point{m.P.X, m.P.Y - 1}) {
+			m.landPiece()
+			m.lastFall = now
+			break
+		}
+
+		m.lowerPiece()
+		m.lastFall = next
+	}
+
+	m.lockPiece(now)
+
+	if now.Sub(m.lastGarbage) >= GarbageInterval {
+		m.lastGarbage = now
+		m.receiveGarbage()
 	}
 }
 
@@ -110,8 +191,26 @@ func (m *Matrix) takePiece() bool {
 		return false
 	}
 
-	p := NewPiece(m.Bag.Take(), Point{0, 0})
+	mn := m.Bag.Take()
+	if mn == nil {
+		// Play the held piece once a fixed sequence is exhausted
+		if m.Hold != nil {
+			held := m.Hold
+			m.Hold = nil
+			return m.spawnPiece(NewPiece(held.original, Point{0, 0}))
+		}
+
+		if m.Puzzle != nil {
+			m.Event <- &event.PuzzleEvent{Solved: false}
+		}
+
+		return false
+	}
+
+	return m.spawnPiece(NewPiece(mn, Point{0, 0}))
+}
 
+func (m *Matrix) spawnPiece(p *Piece) bool {
 	spawn := m.SpawnLocation(p)
 	if spawn.X < 0 || spawn.Y < 0 {
 		return false
@@ -132,6 +231,44 @@ func (m *Matrix) TakePiece() bool {
 	return m.takePiece()
 }
 
+// HoldPiece swaps the active piece with the held piece, or with the next piece
+// when no piece is held. A piece may only be held once per turn.
+func (m *Matrix) HoldPiece() bool {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.Type != MatrixStandard || m.GameOver || m.P == nil || m.HoldUsed {
+		return false
+	}
+
+	p := m.P
+	p.Lock()
+	if p.landed {
+		p.Unlock()
+		return false
+	}
+	p.landed = true // Stop landing the piece being held
+	p.Unlock()
+
+	held := m.Hold
+	m.Hold = NewPiece(p.original, Point{0, 0})
+	m.HoldUsed = true
+
+	var spawned bool
+	if held == nil {
+		spawned = m.takePiece()
+	} else {
+		spawned = m.spawnPiece(NewPiece(held.original, Point{0, 0}))
+	}
+	if !spawned {
+		m.Event <- &event.GameOverEvent{}
+	}
+
+	m.Draw()
+
+	return true
+}
+
 func (m *Matrix) CanAddAt(mn *Piece, loc Point) bool {
 	m.Lock()
 	defer m.Unlock()
@@ -245,6 +382,52 @@ func (m *Matrix) Empty(loc Point) bool {
 	return m.M[index] == BlockNone
 }
 
+// empty returns whether the matrix contains no blocks.
+func (m *Matrix) empty() bool {
+	for _, b := range m.M {
+		if b != BlockNone {
+			return false
+		}
+	}
+
+	return true
+}
+
+// StackHeight returns the number of rows up to and including the highest row
+// containing any blocks.
+func (m *Matrix) StackHeight() int {
+	m.Lock()
+	defer m.Unlock()
+
+	for y := m.H + m.B - 1; y >= 0; y-- {
+		for x := 0; x < m.W; x++ {
+			if m.M[I(x, y, m.W)] != BlockNone {
+				return y + 1
+			}
+		}
+	}
+
+	return 0
+}
+
+// GarbageRows returns the number of rows containing any garbage blocks.
+func (m *Matrix) GarbageRows() int {
+	m.Lock()
+	defer m.Unlock()
+
+	var rows int
+	for y := 0; y < m.H+m.B; y++ {
+		for x := 0; x < m.W; x++ {
+			if m.M[I(x, y, m.W)] == BlockGarbage {
+				rows++
+				break
+			}
+		}
+	}
+
+	return rows
+}
+
 func (m *Matrix) LineFilled(y int) bool {
 	for x := 0; x < m.W; x++ {
 		if m.Empty(Point{x, y}) {
@@ -290,29 +473,129 @@ func (m *Matrix) AddPendingGarbage(lines int) {
 	defer m.Unlock()
 
 	if m.PendingGarbage == 0 {
-		m.PendingGarbageTime = time.Now().Add(GarbageDelay)
+		m.PendingGarbageTime = m.now().Add(GarbageDelay)
 	}
 
 	m.PendingGarbage += lines
+	m.garbageAttacks = append(m.garbageAttacks, lines)
+}
+
+// UpdatePendingGarbageWait records the time remaining until pending garbage
+// lands. It is called before the matrix is sent, as clocks may differ.
+func (m *Matrix) UpdatePendingGarbageWait() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.PendingGarbageWait = 0
+	if m.PendingGarbage > 0 {
+		if wait := m.PendingGarbageTime.Sub(m.now()); wait > 0 {
+			m.PendingGarbageWait = wait
+		}
+	}
+}
+
+// cancelPendingGarbage removes pending garbage lines, oldest first, and
+// returns the number of lines which were not cancelled.
+func (m *Matrix) cancelPendingGarbage(lines int) int {
+	cancel := lines
+	if cancel > m.PendingGarbage {
+		cancel = m.PendingGarbage
+	}
+	m.PendingGarbage -= cancel
+
+	for remaining := cancel; remaining > 0 && len(m.garbageAttacks) > 0; {
+		c := remaining
+		if c > m.garbageAttacks[0] {
+			c = m.garbageAttacks[0]
+		}
+
+		m.garbageAttacks[0] -= c
+		remaining -= c
+
+		if m.garbageAttacks[0] == 0 {
+			m.garbageAttacks = m.garbageAttacks[1:]
+			m.garbageReceived = 0
+		}
+	}
+
+	return lines - cancel
 }
 
 func (m *Matrix) ReceiveGarbage() {
 	m.Lock()
 	defer m.Unlock()
 
+	m.receiveGarbage()
+}
+
+func (m *Matrix) receiveGarbage() {
 	if m.PendingGarbage == 0 || m.GameOver {
 		return
-	} else if time.Since(m.PendingGarbageTime) < 0 {
+	} else if m.now().Before(m.PendingGarbageTime) {
 		return
 	}
 
-	m.PendingGarbage--
-	if !m.addGarbage(1) {
+	if !m.addGarbage(1, m.takePendingGarbage()) {
 		m.Event <- &event.GameOverEvent{}
 	}
 }
 
-func (m *Matrix) addGarbage(lines int) bool {
+// takePendingGarbage takes a line of pending garbage and returns whether it is
+// the first line of its attack.
+func (m *Matrix) takePendingGarbage() bool {
+	firstLine := len(m.garbageAttacks) == 0 || m.garbageReceived == 0
+
+	if m.PendingGarbage > 0 {
+		m.PendingGarbage--
+	}
+	if len(m.garbageAttacks) > 0 {
+		m.garbageAttacks[0]--
+		m.garbageReceived++
+
+		if m.garbageAttacks[0] == 0 {
+			m.garbageAttacks = m.garbageAttacks[1:]
+			m.garbageReceived = 0
+		}
+	}
+
+	return firstLine
+}
+
+// nextGarbageHole returns the column of the hole of a line of garbage. Lines
+// after the first line of an attack may share the hole of the previous line,
+// as may the first line of an attack when garbage is clean.
+func (m *Matrix) nextGarbageHole(firstLine bool) int {
+	continued := m.garbageHoleSet && (!firstLine || m.Rules.Garbage == GarbageClean)
+
+	switch m.Rules.Messiness {
+	case MessinessAttack:
+		if continued {
+			return m.garbageHole
+		}
+	case MessinessChance:
+		if continued && !m.Bag.GarbageChance(m.Rules.HoleChance()) {
+			return m.garbageHole
+		}
+	}
+
+	m.garbageHole = m.Bag.GarbageHole()
+	m.garbageHoleSet = true
+	return m.garbageHole
+}
+
+// AddGarbage raises lines of garbage immediately, without delay. The lines are
+// treated as a single attack. False is returned when the stack is pushed out of
+// the matrix.
+func (m *Matrix) AddGarbage(lines int) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.addGarbage(lines, true)
+}
+
+// addGarbage raises lines of garbage. The first line starts a new attack when
+// firstLine is true, otherwise it continues the previous attack.
+func (m *Matrix) addGarbage(lines int, firstLine bool) bool {
 	for my := (m.H + m.B) - 1; my >= 0; my-- {
 		for mx := 0; mx < m.W; mx++ {
 			if my >= (m.H+m.B-1)-lines {
@@ -328,7 +611,9 @@ func (m *Matrix) addGarbage(lines int) bool {
 	}
 
 	for my := 0; my < lines; my++ {
-		hole := m.Bag.GarbageHole()
+		hole := m.nextGarbageHole(firstLine)
+		firstLine = false
+
 		for mx := 0; mx < m.W; mx++ {
 			if mx == hole {
 				m.M[I(mx, my, m.W)] = BlockNone
@@ -338,6 +623,11 @@ func (m *Matrix) addGarbage(lines int) bool {
 		}
 	}
 
+	if m.P == nil {
+		m.Draw()
+		return true
+	}
+
 	y := m.P.Y
 	for {
 		if y == m.H+m.B {
@@ -388,10 +678,28 @@ func (m *Matrix) Reset() {
 
 	m.GameOver = false
 	m.P = nil
+	m.Hold = nil
+	m.HoldUsed = false
 	m.lands = nil
 	m.Speed = 0
+	m.Score = 0
+	m.Level = 1
+	m.StartLevel = 0
+	m.LinesCleared = 0
+	m.Pieces = 0
+	m.PerfectClears = 0
+	m.scoreReported = 0
+	m.BackToBack = 0
 	m.PendingGarbage = 0
 	m.PendingGarbageTime = time.Time{}
+	m.PendingGarbageWait = 0
+	m.garbageAttacks = nil
+	m.garbageReceived = 0
+	m.garbageHole = 0
+	m.garbageHoleSet = false
+	m.lastFall = time.Time{}
+	m.lastGarbage = time.Time{}
+	m.Puzzle = nil
 	m.Unlock()
 
 	m.Clear()
@@ -463,6 +771,27 @@ func (m *Matrix) SetGameOver() {
 	m.setGameOver()
 }
 
+// Finish ends the game without topping out. The matrix is left as it is.
+func (m *Matrix) Finish() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.finish()
+}
+
+func (m *Matrix) finish() {
+	if m.GameOver {
+		return
+	}
+
+	m.GameOver = true
+	m.Combo = 0
+	m.ComboStart = time.Time{}
+	m.ComboEnd = time.Time{}
+
+	m.Draw()
+}
+
 func (m *Matrix) setGameOver() {
 	if m.GameOver {
 		return
@@ -473,6 +802,10 @@ func (m *Matrix) setGameOver() {
 	m.ComboStart = time.Time{}
 	m.ComboEnd = time.Time{}
 
+	if m.draw == nil {
+		return // Matrixes which are not drawn are not animated
+	}
+
 	go func() {
 		for y := 0; y < m.H+m.B-1; y++ {
 			m.Lock()
@@ -486,22 +819,7 @@ func (m *Matrix) setGameOver() {
 			for x := 0; x < m.W; x++ {
 				i := I(x, y, m.W)
 
-				switch m.M[i] {
-				case BlockSolidJ:
-					m.M[i] = BlockGhostJ
-				case BlockSolidI:
-					m.M[i] = BlockGhostI
-				case BlockSolidS:
-					m.M[i] = BlockGhostS
-				case BlockSolidT:
-					m.M[i] = BlockGhostT
-				case BlockSolidL:
-					m.M[i] = BlockGhostL
-				case BlockSolidZ:
-					m.M[i] = BlockGhostZ
-				case BlockSolidO:
-					m.M[i] = BlockGhostO
-				}
+				m.M[i] = m.M[i].Ghost()
 			}
 
 			m.Draw()
@@ -550,14 +868,16 @@ func (m *Matrix) RotatePiece(rotations int, direction int) bool {
 	originalMino := make(Mino, len(p.Mino))
 	copy(originalMino, p.Mino)
 
+	offsets := p.Offsets(m.Rules.RotationSystem, rotations, direction)
+
 	p.Mino = p.Rotate(rotations, direction)
 
-	for i := range AllOffsets {
-		px := p.X + AllOffsets[i].X
-		py := p.Y + AllOffsets[i].Y
+	for i := range offsets {
+		px := p.X + offsets[i].X
+		py := p.Y + offsets[i].Y
 
 		if m.canAddAt(p, Point{px, py}) {
-			p.ApplyReset()
+			p.ApplyReset(m.Rules, py, m.now())
 
 			if p.X != px || p.Y != py {
 				p.SetLocation(px, py)
@@ -565,6 +885,14 @@ func (m *Matrix) RotatePiece(rotations int, direction int) bool {
 
 			p.ApplyRotation(rotations, direction)
 
+			p.rotated = true
+			p.kick = i
+			p.halfTurn = rotations == 2
+
+			if m.gravity20G() {
+				m.dropPiece()
+			}
+
 			m.Draw()
 
 			return true
@@ -628,20 +956,29 @@ func (m *Matrix) lowerPiece() {
 	if m.GameOver {
 		return
 	} else if m.canAddAt(m.P, Point{m.P.X, m.P.Y - 1}) {
-		m.movePiece(0, -1)
+		if m.gravity20G() {
+			m.dropPiece()
+		} else {
+			m.movePiece(0, -1)
+		}
 	} else {
 		m.landPiece()
 	}
 }
 
-func (m *Matrix) finishLandingPiece() {
+// finishLandingPiece lowers the active piece as far as it will go and locks it
+// in place. Drop points are only awarded when the piece is hard dropped.
+func (m *Matrix) finishLandingPiece(hardDrop bool) {
 	if m.GameOver || m.P.landed {
 		return
 	}
 
 	m.P.landed = true
 
-	dropped := false
+	var (
+		dropped bool
+		spin    SpinType
+	)
 LANDPIECE:
 	for y := m.P.Y; y >= 0; y-- {
 		if y == 0 || !m.canAddAt(m.P, Point{m.P.X, y - 1}) {
@@ -650,6 +987,14 @@ LANDPIECE:
 					continue
 				}
 
+				if dropY != m.P.Y {
+					m.P.rotated = false
+					if hardDrop {
+						m.Score += ScoreHardDrop * (m.P.Y - dropY)
+					}
+				}
+				spin = m.detectSpin(m.P, Point{m.P.X, dropY})
+
 				err := m.add(m.P, m.P.Solid, Point{m.P.X, dropY}, false)
 				if err != nil {
 					log.Fatalf("failed to add piece when landing piece: %+v", err)
@@ -662,36 +1007,51 @@ LANDPIECE:
 	}
 
 	if !dropped {
-		m.Event <- event.GameOverEvent{}
+		m.Event <- &event.GameOverEvent{}
 
 		m.Draw()
 		return
 	}
 
+	m.Pieces++
+
 	cleared := m.clearFilled()
 
-	score := 0
-	switch cleared {
-	case 0:
-		// No score
-	case 1:
-		score = 100
-	case 2:
-		score = 300
-	case 3:
-		score = 500
-	case 4:
-		score = 800
-	default:
-		score = 1000 + ((cleared - 5) * 200)
+	if spin != SpinNone {
+		m.Event <- &event.Event{Message: SpinName(spin, cleared)}
+	}
+
+	perfectClear := cleared > 0 && m.empty()
+	if perfectClear {
+		m.PerfectClears++
+		m.Score += ScorePerfectClear * m.Level
+
+		m.Event <- &event.PerfectClearEvent{}
+	}
+
+	score := LineClearScore(cleared, spin) * m.Level
+	if cleared > 0 {
+		if Difficult(cleared, spin) {
+			m.BackToBack++
+			if m.BackToBack > 1 {
+				score = score * 3 / 2
+			}
+		} else {
+			m.BackToBack = 0
+		}
 	}
+	m.Score += score
 
-	_ = score
+	m.LinesCleared += cleared
+	if m.Rules.Gravity == GravityLevel {
+		m.setLevel(m.startLevel() + m.LinesCleared/LinesPerLevel)
+	}
 
 	m.moved()
 
+	now := m.now()
 	for i := range m.lands {
-		if time.Since(m.lands[i]) > 2*time.Minute {
+		if now.Sub(m.lands[i]) > 2*time.Minute {
 			continue
 		}
 
@@ -700,27 +1060,25 @@ LANDPIECE:
 		}
 		break
 	}
-	m.lands = append(m.lands, time.Now())
+	m.lands = append(m.lands, now)
 
 	numlands := len(m.lands)
-	if numlands > 1 {
-		m.Speed = int(time.Minute / (time.Since(m.lands[0]) / time.Duration(numlands)))
+	if elapsed := now.Sub(m.lands[0]); numlands > 1 && elapsed >= time.Duration(numlands) {
+		m.Speed = int(time.Minute / (elapsed / time.Duration(numlands)))
 	}
 
 	if cleared > 0 {
-		sendGarbage := m.addToCombo(cleared)
+		sendGarbage := m.addToCombo(cleared, spin)
+		if perfectClear {
+			sendGarbage += m.Rules.PerfectClearGarbage()
+		}
+
+		if m.Combo > 1 {
+			m.Score += ScoreCombo * (m.Combo - 1) * m.Level
+		}
+
 		if sendGarbage > 0 {
-			remainingGarbage := sendGarbage
-			if m.PendingGarbage > 0 {
-				m.PendingGarbage -= sendGarbage
-
-				if m.PendingGarbage < 0 {
-					remainingGarbage = m.PendingGarbage * -1
-					m.PendingGarbage = 0
-				} else {
-					remainingGarbage = 0
-				}
-			}
+			remainingGarbage := m.cancelPendingGarbage(sendGarbage)
 
 			if remainingGarbage > 0 {
 				m.Event <- &event.SendGarbageEvent{Lines: remainingGarbage}
@@ -728,6 +1086,18 @@ LANDPIECE:
 		}
 	}
 
+	if m.Score > m.scoreReported {
+		m.Event <- &event.ScoreEvent{Score: m.Score - m.scoreReported}
+		m.scoreReported = m.Score
+	}
+
+	m.HoldUsed = false
+
+	if m.Puzzle != nil && m.puzzleLanded(cleared, spin, perfectClear) {
+		m.Draw()
+		return
+	}
+
 	if !m.takePiece() {
 		m.Event <- &event.GameOverEvent{}
 	}
@@ -735,7 +1105,7 @@ LANDPIECE:
 	m.Draw()
 }
 
-func (m *Matrix) addToCombo(lines int) int {
+func (m *Matrix) addToCombo(lines int, spin SpinType) int {
 	if m.GameOver {
 		return 0
 	}
@@ -743,9 +1113,9 @@ func (m *Matrix) addToCombo(lines int) int {
 	baseTime := ComboBaseTime
 	bonusTime := baseTime / 2
 
-	if m.Combo == 0 || time.Until(m.ComboEnd) <= 0 {
+	if now := m.now(); m.Combo == 0 || !now.Before(m.ComboEnd) {
 		m.Combo = 0
-		m.ComboStart = time.Now()
+		m.ComboStart = now
 		m.ComboEnd = m.ComboStart
 	}
 
@@ -758,12 +1128,9 @@ func (m *Matrix) addToCombo(lines int) int {
 
 	m.ComboEnd = m.ComboEnd.Add(time.Duration((baseTime * float64(time.Second)) + (bonusTime * float64(lines) * float64(time.Second))))
 
-	baseGarbage := 0
-	if lines > 1 {
-		baseGarbage = lines - 1
-	}
+	baseGarbage := SpinGarbage(spin, lines)
 
-	bonusGarbage := m.CalculateBonusGarbage()
+	bonusGarbage := m.CalculateBonusGarbage() + BackToBackGarbage(m.BackToBack-1)
 
 	return baseGarbage + bonusGarbage
 }
@@ -786,59 +1153,134 @@ func (m *Matrix) CalculateBonusGarbage() int {
 	return bonusGarbage
 }
 
+// landPiece starts the lock delay of the active piece. The piece is locked in
+// place by Tick once the delay expires.
 func (m *Matrix) landPiece() {
 	p := m.P
 	p.Lock()
+	defer p.Unlock()
+
 	if p.landing || p.landed || m.GameOver {
-		p.Unlock()
 		return
 	}
 
 	p.landing = true
+	p.landStart = m.now()
+	p.lowest = p.Y
+}
+
+// lockPiece locks the active piece in place when its lock delay has expired.
+func (m *Matrix) lockPiece(now time.Time) {
+	p := m.P
+	p.Lock()
+	if !p.landing || p.landed {
+		p.Unlock()
+		return
+	}
+
+	lockDelay := m.Rules.LockDelayTime()
+	if now.Sub(p.landStart) < lockDelay || (p.resets > 0 && now.Sub(p.lastReset) < lockDelay) {
+		p.Unlock()
+		return
+	}
 	p.Unlock()
 
-	go func() {
-		landStart := time.Now()
+	m.finishLandingPiece(false)
+}
 
-		t := time.NewTicker(100 * time.Millisecond)
-		for {
-			<-t.C
+func (m *Matrix) MovePiece(x int, y int) bool {
+	m.Lock()
+	defer m.Unlock()
 
-			m.Lock()
-			p.Lock()
+	return m.movePiece(x, y)
+}
 
-			if p.landed {
-				p.Unlock()
-				m.Unlock()
-				return
-			}
+// dropPiece moves the active piece as far down as possible without landing it.
+func (m *Matrix) dropPiece() {
+	y := m.P.Y
+	for m.canAddAt(m.P, Point{m.P.X, y - 1}) {
+		y--
+	}
+	if y == m.P.Y {
+		return
+	}
 
-			if p.resets > 0 && time.Since(p.lastReset) < 500*time.Millisecond {
-				p.Unlock()
-				m.Unlock()
-				continue
-			} else if time.Since(landStart) < 500*time.Millisecond {
-				p.Unlock()
-				m.Unlock()
-				continue
-			}
+	m.P.SetLocation(m.P.X, y)
+	m.P.rotated = false
 
-			t.Stop()
-			break
-		}
+	m.landPiece()
+	m.Draw()
+}
 
-		p.Unlock()
+func (m *Matrix) gravity20G() bool {
+	return m.Rules.Gravity != GravityStatic && m.Level >= Gravity20GLevel
+}
 
-		m.finishLandingPiece()
-		m.Unlock()
-	}()
+// SetLevel sets the level, which determines gravity and score multiplier.
+func (m *Matrix) SetLevel(level int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.setLevel(level)
 }
 
-func (m *Matrix) MovePiece(x int, y int) bool {
+func (m *Matrix) setLevel(level int) {
+	if level < 1 {
+		level = 1
+	}
+	if level == m.Level {
+		return
+	}
+
+	levelUp := level > m.Level
+	m.Level = level
+
+	if levelUp && m.Type == MatrixStandard && !m.GameOver {
+		m.Event <- &event.Event{Message: fmt.Sprintf("Level %d", level)}
+	}
+}
+
+// SetStartLevel sets the level at the start of the game. Levels advance from
+// the start level as lines are cleared.
+func (m *Matrix) SetStartLevel(level int) {
 	m.Lock()
 	defer m.Unlock()
 
-	return m.movePiece(x, y)
+	m.StartLevel = level
+	m.setLevel(m.startLevel() + m.LinesCleared/LinesPerLevel)
+}
+
+func (m *Matrix) startLevel() int {
+	if m.StartLevel < 1 {
+		return 1
+	}
+
+	return m.StartLevel
+}
+
+// FallTime returns the time the active piece takes to fall one row.
+func (m *Matrix) FallTime(base time.Duration) time.Duration {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.Rules.Gravity == GravityStatic {
+		return base
+	}
+
+	return FallTime(base, m.Level)
+}
+
+// SoftDropPiece moves the active piece down one row.
+func (m *Matrix) SoftDropPiece() bool {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.movePiece(0, -1) {
+		return false
+	}
+
+	m.Score += ScoreSoftDrop
+	return true
 }
 
 func (m *Matrix) movePiece(x int, y int) bool {
@@ -853,8 +1295,13 @@ func (m *Matrix) movePiece(x int, y int) bool {
 		return false
 	}
 
-	m.P.ApplyReset()
+	m.P.ApplyReset(m.Rules, py, m.now())
 	m.P.SetLocation(px, py)
+	m.P.rotated = false
+
+	if m.gravity20G() {
+		m.dropPiece()
+	}
 
 	if !m.canAddAt(m.P, Point{m.P.X, m.P.Y - 1}) {
 		m.landPiece()
@@ -869,39 +1316,16 @@ func (m *Matrix) movePiece(x int, y int) bool {
 	return true
 }
 
-func (m *Matrix) Moved() {
-	m.Lock()
-	if m.Move == nil {
-		m.Unlock()
-		return
-	}
-
-	select {
-	case m.Move <- 0:
-		m.Unlock()
-	default:
-		m.Unlock()
-		m.SetGameOver()
-	}
-}
-
+// moved restarts the fall time of the active piece.
 func (m *Matrix) moved() {
-	if m.Move == nil {
-		return
-	}
-
-	select {
-	case m.Move <- 0:
-	default:
-		m.setGameOver()
-	}
+	m.lastFall = m.now()
 }
 
 func (m *Matrix) HardDropPiece() {
 	m.Lock()
 	defer m.Unlock()
 
-	m.finishLandingPiece()
+	m.finishLandingPiece(true)
 }
 
 func (m *Matrix) ValidPoint(x int, y int) bool {
@@ -918,11 +1342,23 @@ func (m *Matrix) Replace(newmtx *Matrix) {
 
 	m.M = newmtx.M
 	m.P = newmtx.P
+	m.Hold = newmtx.Hold
+	m.HoldUsed = newmtx.HoldUsed
 
 	m.PlayerName = newmtx.PlayerName
 	m.GarbageSent = newmtx.GarbageSent
 	m.GarbageReceived = newmtx.GarbageReceived
 	m.Speed = newmtx.Speed
+	m.Score = newmtx.Score
+	m.Level = newmtx.Level
+	m.LinesCleared = newmtx.LinesCleared
+	m.Pieces = newmtx.Pieces
+	m.PerfectClears = newmtx.PerfectClears
+	m.BackToBack = newmtx.BackToBack
+
+	m.PendingGarbage = newmtx.PendingGarbage
+	m.PendingGarbageTime = m.now().Add(newmtx.PendingGarbageWait)
+	m.PendingGarbageWait = newmtx.PendingGarbageWait
 }
 
 func fibonacci(value int) int {
@@ -952,7 +1388,7 @@ func NewTestMatrix() (*Matrix, error) {
 
 	m := NewMatrix(10, 20, 4, 1, ev, draw, MatrixStandard)
 
-	bag, err := NewBag(1, minos, 10)
+	bag, err := NewBag(1, minos, 10, RandomizerBag)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate minos: %s", err)
 	}
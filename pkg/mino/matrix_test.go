@@ -1,7 +1,11 @@
 package mino
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
 )
 
 func TestMatrix(t *testing.T) {
@@ -77,13 +81,662 @@ func TestMatrix(t *testing.T) {
 		}
 	}
 
-	ok = m.addGarbage(1)
+	ok = m.addGarbage(1, true)
 	if !ok {
 		t.Error("failed to add 1 line of garbage")
 	}
 
-	ok = m.addGarbage(3)
+	ok = m.addGarbage(3, true)
 	if !ok {
 		t.Error("failed to add 3 line of garbage")
 	}
 }
+
+func TestHoldPiece(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	first := m.P.original
+	next := m.Bag.Next()
+
+	if !m.HoldPiece() {
+		t.Fatal("failed to hold piece")
+	}
+	if m.Hold == nil || m.Hold.Mino.String() != first.String() {
+		t.Errorf("failed to hold piece, wanted %s got %+v", first, m.Hold)
+	}
+	if m.P.original.String() != next.String() {
+		t.Errorf("failed to take next piece after hold, wanted %s got %s", next, m.P.original)
+	}
+
+	if m.HoldPiece() {
+		t.Error("held piece twice during the same turn")
+	}
+
+	m.HoldUsed = false
+
+	if !m.HoldPiece() {
+		t.Fatal("failed to swap held piece")
+	}
+	if m.P.original.String() != first.String() || m.Hold.Mino.String() != next.String() {
+		t.Errorf("failed to swap held piece, wanted %s/%s got %s/%s", first, next, m.P.original, m.Hold.Mino)
+	}
+}
+
+func TestRotatePieceSRS(t *testing.T) {
+	t.Parallel()
+
+	for _, rs := range []RotationSystem{RotationClassic, RotationSRS} {
+		m, err := NewTestMatrix()
+		if err != nil {
+			t.Error(err)
+		}
+
+		m.Rules.RotationSystem = rs
+		m.P = NewPiece(NewMino(TetrominoT), Point{4, 0})
+
+		ok := m.RotatePiece(1, 0)
+		if rs == RotationClassic {
+			if ok {
+				t.Errorf("unexpected floor kick using %s rotation system", rs)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Fatalf("failed to rotate piece using %s rotation system", rs)
+		} else if m.P.X != 3 || m.P.Y != 1 || m.P.Rotation != RotationR {
+			t.Errorf("failed to apply floor kick using %s rotation system: got %s rotation %d", rs, m.P.Point, m.P.Rotation)
+		}
+
+		for i := 0; i < 3; i++ {
+			if !m.RotatePiece(1, 0) {
+				t.Errorf("failed to rotate piece using %s rotation system on iteration %d", rs, i)
+			}
+		}
+
+		if m.P.Rotation != Rotation0 {
+			t.Errorf("failed to rotate piece using %s rotation system: expected rotation %d, got %d", rs, Rotation0, m.P.Rotation)
+		}
+	}
+}
+
+func TestSpin(t *testing.T) {
+	t.Parallel()
+
+	for _, rotated := range []bool{false, true} {
+		m, err := NewTestMatrix()
+		if err != nil {
+			t.Error(err)
+		}
+
+		ev := make(chan interface{}, 10)
+		m.Event = ev
+
+		m.Clear()
+		for x := 0; x < m.W; x++ {
+			if x != 4 {
+				m.SetBlock(x, 0, BlockGarbage, false)
+			}
+			if x < 3 || x > 5 {
+				m.SetBlock(x, 1, BlockGarbage, false)
+			}
+		}
+		m.SetBlock(3, 2, BlockGarbage, false)
+
+		p := NewPiece(NewMino(TetrominoT), Point{3, 1})
+		p.Mino = NewMino("(1,-1),(0,0),(1,0),(2,0)")
+		p.Rotation = Rotation2
+		p.rotated = rotated
+		m.P = p
+
+		m.HardDropPiece()
+
+		var (
+			message string
+			garbage int
+		)
+		for len(ev) > 0 {
+			switch e := (<-ev).(type) {
+			case *event.Event:
+				message = e.Message
+			case *event.SendGarbageEvent:
+				garbage = e.Lines
+			}
+		}
+
+		expectedMessage, expectedGarbage, expectedScore := "", 1, 300
+		if rotated {
+			expectedMessage, expectedGarbage, expectedScore = "T-Spin Double", 4, 1200
+		}
+		if message != expectedMessage {
+			t.Errorf("failed to detect spin (rotated: %v): expected message %q, got %q", rotated, expectedMessage, message)
+		}
+		if garbage != expectedGarbage {
+			t.Errorf("failed to detect spin (rotated: %v): expected %d garbage lines, got %d", rotated, expectedGarbage, garbage)
+		}
+		if m.Score != expectedScore {
+			t.Errorf("failed to score spin (rotated: %v): expected score %d, got %d", rotated, expectedScore, m.Score)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ev := make(chan interface{}, 10)
+	m.Event = ev
+
+	m.Rules.Gravity = GravityLevel
+	m.LinesCleared = LinesPerLevel - 1
+
+	m.Clear()
+	for x := 0; x < m.W; x++ {
+		if x < 3 || x > 6 {
+			m.SetBlock(x, 0, BlockGarbage, false)
+		}
+	}
+
+	m.P = NewPiece(NewMino(TetrominoI), Point{3, 5})
+
+	m.HardDropPiece()
+
+	var levelUp bool
+	for len(ev) > 0 {
+		if e, ok := (<-ev).(*event.Event); ok && e.Message == "Level 2" {
+			levelUp = true
+		}
+	}
+
+	if m.Level != 2 || !levelUp {
+		t.Errorf("failed to level up: expected level 2, got %d", m.Level)
+	}
+	if m.LinesCleared != LinesPerLevel {
+		t.Errorf("failed to count lines cleared: expected %d, got %d", LinesPerLevel, m.LinesCleared)
+	}
+
+	base := 850 * time.Millisecond
+	if ft := m.FallTime(base); ft >= base {
+		t.Errorf("failed to increase gravity: expected fall time below %s, got %s", base, ft)
+	}
+
+	m.SetStartLevel(5)
+	if m.Level != 6 {
+		t.Errorf("failed to set start level: expected level 6, got %d", m.Level)
+	}
+
+	m.SetLevel(Gravity20GLevel)
+	m.P = NewPiece(NewMino(TetrominoO), Point{4, 10})
+	m.LowerPiece()
+	if m.P.Y != 0 {
+		t.Errorf("failed to apply 20G: expected piece at y 0, got %d", m.P.Y)
+	}
+}
+
+func TestGarbage(t *testing.T) {
+	t.Parallel()
+
+	for _, messiness := range []GarbageMessiness{MessinessRandom, MessinessChance, MessinessAttack} {
+		for _, style := range []GarbageStyle{GarbageCheese, GarbageClean} {
+			m, err := NewTestMatrix()
+			if err != nil {
+				t.Error(err)
+			}
+
+			m.Rules.Garbage = style
+			m.Rules.Messiness = messiness
+
+			m.AddPendingGarbage(6)
+			m.AddPendingGarbage(2)
+			m.PendingGarbageTime = time.Time{}
+			for i := 0; i < 8; i++ {
+				m.ReceiveGarbage()
+			}
+
+			if m.PendingGarbage != 0 {
+				t.Errorf("failed to receive %s %s garbage: %d lines pending", messiness, style, m.PendingGarbage)
+			}
+
+			holes := make([]int, 8)
+			for y := range holes {
+				holes[y] = -1
+				for x := 0; x < m.W; x++ {
+					if m.Block(x, y) == BlockNone {
+						if holes[y] >= 0 {
+							t.Errorf("failed to receive %s %s garbage: line %d has multiple holes", messiness, style, y)
+						}
+						holes[y] = x
+					}
+				}
+			}
+
+			if messiness != MessinessAttack {
+				continue
+			}
+
+			// The first attack is pushed above the second attack
+			for y := 3; y < 8; y++ {
+				if holes[y] != holes[2] {
+					t.Errorf("failed to receive %s garbage: expected hole at %d on line %d, got %d", style, holes[2], y, holes[y])
+				}
+			}
+			if holes[1] != holes[0] {
+				t.Errorf("failed to receive %s garbage: expected hole at %d on line 1, got %d", style, holes[0], holes[1])
+			}
+			if style == GarbageClean && holes[2] != holes[1] {
+				t.Errorf("failed to receive clean garbage: expected attacks to share hole %d, got %d", holes[2], holes[1])
+			}
+		}
+	}
+}
+
+func TestGarbageChance(t *testing.T) {
+	t.Parallel()
+
+	for i, chance := range []int{0, -1, MinGarbageChance, 50, MaxGarbageChance, 100} {
+		r := Rules{GarbageChance: chance}
+		r.Validate()
+
+		expected := chance
+		if chance < MinGarbageChance || chance > MaxGarbageChance {
+			expected = DefaultGarbageChance
+		}
+		if r.HoleChance() != expected {
+			t.Errorf("case %d: failed to validate garbage chance: expected %d, got %d", i, expected, r.HoleChance())
+		}
+	}
+}
+
+func TestAddGarbage(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	m.Clear()
+	m.P = nil
+
+	if !m.AddGarbage(4) {
+		t.Fatal("failed to add garbage")
+	}
+	if rows := m.GarbageRows(); rows != 4 {
+		t.Errorf("failed to add garbage: expected 4 garbage rows, got %d", rows)
+	}
+
+	for x := 0; x < m.W; x++ {
+		m.M[I(x, 0, m.W)] = BlockSolidI
+	}
+	if rows := m.GarbageRows(); rows != 3 {
+		t.Errorf("failed to count garbage rows: expected 3, got %d", rows)
+	}
+
+	if m.AddGarbage(m.H + m.B) {
+		t.Error("failed to overflow matrix when adding garbage")
+	}
+
+	// Garbage added at once is a single attack
+	m.Clear()
+	m.Rules.Messiness = MessinessAttack
+	for i := 0; i < 10; i++ {
+		if !m.AddGarbage(4) {
+			t.Fatal("failed to add garbage attack")
+		}
+
+		hole := -1
+		for y := 0; y < 4; y++ {
+			for x := 0; x < m.W; x++ {
+				if m.Block(x, y) != BlockNone {
+					continue
+				}
+
+				if hole == -1 {
+					hole = x
+				} else if x != hole {
+					t.Errorf("failed to add garbage attack: expected hole at %d on line %d, got %d", hole, y, x)
+				}
+			}
+		}
+
+		m.Clear()
+	}
+}
+
+func TestSpawnLocation(t *testing.T) {
+	t.Parallel()
+
+	for _, w := range []int{MinWidth, 5, DefaultWidth, MaxWidth} {
+		m := NewMatrix(w, MinHeight, 4, 1, nil, nil, MatrixStandard)
+
+		for _, mn := range []string{TetrominoI, TetrominoT, PentominoI} {
+			p := NewPiece(NewMino(mn), Point{0, 0})
+			pw, _ := p.Size()
+			if pw > w {
+				continue
+			}
+
+			loc := m.SpawnLocation(p)
+			if loc.X < 0 || loc.X+pw > w || loc.Y < m.H {
+				t.Errorf("failed to spawn %s in matrix of width %d: invalid location %s", mn, w, loc)
+			}
+		}
+	}
+}
+
+func TestPerfectClear(t *testing.T) {
+	t.Parallel()
+
+	for _, perfectClear := range []int{0, PerfectClearNone} {
+		m, err := NewTestMatrix()
+		if err != nil {
+			t.Error(err)
+		}
+
+		ev := make(chan interface{}, 10)
+		m.Event = ev
+
+		m.Rules.PerfectClear = perfectClear
+
+		m.Clear()
+		for x := 0; x < m.W; x++ {
+			if x < 3 || x > 6 {
+				m.SetBlock(x, 0, BlockGarbage, false)
+			}
+		}
+
+		m.P = NewPiece(NewMino(TetrominoI), Point{3, 5})
+
+		m.HardDropPiece()
+
+		var (
+			announced bool
+			garbage   int
+		)
+		for len(ev) > 0 {
+			switch e := (<-ev).(type) {
+			case *event.PerfectClearEvent:
+				announced = true
+			case *event.SendGarbageEvent:
+				garbage = e.Lines
+			}
+		}
+
+		if !announced || m.PerfectClears != 1 {
+			t.Errorf("failed to detect perfect clear: announced %v, recorded %d", announced, m.PerfectClears)
+		}
+		if expected := m.Rules.PerfectClearGarbage(); garbage != expected {
+			t.Errorf("failed to send perfect clear garbage: expected %d lines, got %d", expected, garbage)
+		}
+		if expected := (ScoreHardDrop * 5) + LineClearScore(1, SpinNone) + ScorePerfectClear; m.Score != expected {
+			t.Errorf("failed to score perfect clear: expected %d, got %d", expected, m.Score)
+		}
+	}
+}
+
+func TestBackToBack(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	m.Clear()
+	for i, lines := range []int{4, 4, 1} {
+		for y := 0; y < lines; y++ {
+			for x := 0; x < m.W-1; x++ {
+				m.SetBlock(x, y, BlockGarbage, false)
+			}
+		}
+
+		mn := "(0,0),(0,1),(0,2),(0,3)"
+		if lines == 1 {
+			mn = Monomino
+		}
+		m.P = NewPiece(NewMino(mn), Point{m.W - 1, 10})
+
+		m.HardDropPiece()
+
+		expected := []int{1, 2, 0}[i]
+		if m.BackToBack != expected {
+			t.Errorf("failed to track back-to-back chain after clear %d: expected %d, got %d", i, expected, m.BackToBack)
+		}
+	}
+
+	for level, expected := range []int{0, 1, 1, 2} {
+		if garbage := BackToBackGarbage(level); garbage != expected {
+			t.Errorf("failed to calculate back-to-back garbage for level %d: expected %d, got %d", level, expected, garbage)
+		}
+	}
+}
+
+func TestFinish(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		m.HardDropPiece()
+	}
+	if m.Pieces != 3 {
+		t.Errorf("failed to count placed pieces: expected 3, got %d", m.Pieces)
+	}
+
+	blocks := make([]Block, len(m.M))
+	copy(blocks, m.M)
+
+	m.Finish()
+	if !m.GameOver {
+		t.Error("failed to finish matrix: game is not over")
+	}
+	for i := range blocks {
+		if m.M[i] != blocks[i] {
+			t.Fatalf("failed to finish matrix: block %d changed from %d to %d", i, blocks[i], m.M[i])
+		}
+	}
+
+	m.Reset()
+	if m.Pieces != 0 {
+		t.Errorf("failed to reset placed pieces: expected 0, got %d", m.Pieces)
+	}
+}
+
+func TestStackHeight(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	m.Clear()
+	if h := m.StackHeight(); h != 0 {
+		t.Errorf("failed to measure empty stack: expected 0, got %d", h)
+	}
+
+	m.SetBlock(0, 0, BlockGarbage, false)
+	m.SetBlock(4, 6, BlockGarbage, false)
+	if h := m.StackHeight(); h != 7 {
+		t.Errorf("failed to measure stack: expected 7, got %d", h)
+	}
+}
+
+func TestPendingGarbage(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	m.AddPendingGarbage(3)
+	m.UpdatePendingGarbageWait()
+	if m.PendingGarbageWait <= 0 || m.PendingGarbageWait > GarbageDelay {
+		t.Errorf("failed to update pending garbage wait: got %s", m.PendingGarbageWait)
+	}
+
+	buf, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("failed to marshal matrix: %s", err)
+	}
+
+	var received Matrix
+	err = json.Unmarshal(buf, &received)
+	if err != nil {
+		t.Fatalf("failed to unmarshal matrix: %s", err)
+	}
+
+	o, err := NewTestMatrix()
+	if err != nil {
+		t.Error(err)
+	}
+
+	o.Replace(&received)
+	if o.PendingGarbage != 3 {
+		t.Errorf("failed to replace pending garbage: expected 3, got %d", o.PendingGarbage)
+	}
+	if wait := time.Until(o.PendingGarbageTime); wait <= 0 || wait > GarbageDelay {
+		t.Errorf("failed to replace pending garbage time: lands in %s", wait)
+	}
+}
+
+func TestRotatePiece180(t *testing.T) {
+	t.Parallel()
+
+	for _, rs := range []RotationSystem{RotationClassic, RotationSRS} {
+		for _, mino := range []string{TetrominoI, TetrominoJ, TetrominoL, TetrominoO, TetrominoS, TetrominoT, TetrominoZ} {
+			a, err := NewTestMatrix()
+			if err != nil {
+				t.Error(err)
+			}
+			a.Rules.RotationSystem = rs
+			a.P = NewPiece(NewMino(mino), Point{3, 10})
+
+			b, err := NewTestMatrix()
+			if err != nil {
+				t.Error(err)
+			}
+			b.Rules.RotationSystem = rs
+			b.P = NewPiece(NewMino(mino), Point{3, 10})
+
+			for i := 0; i < RotationStates; i++ {
+				if !a.RotatePiece(2, 0) {
+					t.Fatalf("failed to rotate %s 180 degrees using %s rotation system", mino, rs)
+				}
+				for j := 0; j < 2; j++ {
+					if !b.RotatePiece(1, 0) {
+						t.Fatalf("failed to rotate %s using %s rotation system", mino, rs)
+					}
+				}
+
+				if a.P.Rotation != b.P.Rotation || a.P.Point != b.P.Point || a.P.Mino.String() != b.P.Mino.String() {
+					t.Errorf("failed to rotate %s 180 degrees using %s rotation system: expected %s rotation %d %s, got %s rotation %d %s", mino, rs, b.P.Point, b.P.Rotation, b.P.Mino, a.P.Point, a.P.Rotation, a.P.Mino)
+				}
+			}
+		}
+	}
+}
+
+func TestDropScore(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewTestMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := NewManualClock(time.Unix(0, 0))
+	m.SetClock(clock)
+
+	// Locking a piece which is not resting on the stack awards no points
+	m.Clear()
+	m.P = NewPiece(NewMino(TetrominoT), Point{3, 10})
+	m.P.landing = true
+	m.P.landStart = clock.Now()
+
+	clock.Advance(m.Rules.LockDelayTime())
+	m.lockPiece(clock.Now())
+	if m.Score != 0 {
+		t.Errorf("failed to lock piece: expected score 0, got %d", m.Score)
+	}
+
+	m.Clear()
+	m.P = NewPiece(NewMino(TetrominoT), Point{3, 10})
+
+	m.HardDropPiece()
+	if expected := ScoreHardDrop * 10; m.Score != expected {
+		t.Errorf("failed to hard drop piece: expected score %d, got %d", expected, m.Score)
+	}
+}
+
+func TestTick(t *testing.T) {
+	t.Parallel()
+
+	const fallTime = 100 * time.Millisecond
+
+	var renders []string
+	for i := 0; i < 2; i++ {
+		m, err := NewTestMatrix()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clock := NewManualClock(time.Unix(0, 0))
+		m.SetClock(clock)
+
+		m.Tick(fallTime)
+		y := m.P.Y
+
+		clock.Advance(fallTime*2 + fallTime/2)
+		m.Tick(fallTime)
+		if m.P.Y != y-2 {
+			t.Fatalf("failed to lower piece: expected row %d, got %d", y-2, m.P.Y)
+		}
+
+		p := m.P
+		for !p.landing {
+			clock.Advance(fallTime)
+			m.Tick(fallTime)
+		}
+
+		clock.Advance(m.Rules.LockDelayTime() - time.Millisecond)
+		m.Tick(fallTime)
+		if m.P != p {
+			t.Fatal("failed to delay locking landed piece")
+		}
+
+		clock.Advance(time.Millisecond)
+		m.Tick(fallTime)
+		if m.P == p {
+			t.Fatal("failed to lock landed piece")
+		}
+
+		m.AddPendingGarbage(2)
+		for j := 0; j < 4; j++ {
+			clock.Advance(GarbageDelay / 2)
+			m.Tick(fallTime)
+		}
+		if m.PendingGarbage != 0 {
+			t.Fatalf("failed to receive garbage: %d lines pending", m.PendingGarbage)
+		}
+
+		renders = append(renders, m.Render())
+	}
+
+	if renders[0] != renders[1] {
+		t.Errorf("failed to tick deterministically: boards differ:\n%s\n%s", renders[0], renders[1])
+	}
+}
@@ -54,6 +54,52 @@ var AllRotationPivotsCCW = [][]Point{
 // Rotation offsets
 var AllOffsets = []Point{{0, 0}, {-1, 0}, {1, 0}, {0, -1}, {-1, -1}, {1, -1}, {-2, 0}, {2, 0}}
 
+// Rotation offsets of 180 degree rotations with classic rotation, and of pieces
+// without SRS offsets
+var Offsets180 = []Point{{0, 0}, {-1, 0}, {1, 0}, {0, 1}, {-1, 1}, {1, 1}, {0, -1}, {-2, 0}, {2, 0}}
+
+// SRS rotation offsets of J, L, S, T and Z pieces, indexed by initial rotation
+// state and clockwise (CW) or counter-clockwise (CCW) rotation
+var (
+	SRSOffsetsCW = [][]Point{
+		Rotation0: {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+		RotationR: {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+		Rotation2: {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+		RotationL: {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	}
+	SRSOffsetsCCW = [][]Point{
+		Rotation0: {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+		RotationR: {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+		Rotation2: {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+		RotationL: {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	}
+)
+
+// SRS rotation offsets of I pieces
+var (
+	SRSOffsetsICW = [][]Point{
+		Rotation0: {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}},
+		RotationR: {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}},
+		Rotation2: {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}},
+		RotationL: {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}},
+	}
+	SRSOffsetsICCW = [][]Point{
+		Rotation0: {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}},
+		RotationR: {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}},
+		Rotation2: {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}},
+		RotationL: {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}},
+	}
+)
+
+// SRS rotation offsets of 180 degree rotations of all pieces except O,
+// indexed by initial rotation state
+var SRSOffsets180 = [][]Point{
+	Rotation0: {{0, 0}, {0, 1}, {1, 1}, {-1, 1}, {1, 0}, {-1, 0}},
+	RotationR: {{0, 0}, {1, 0}, {1, 2}, {1, 1}, {0, 2}, {0, 1}},
+	Rotation2: {{0, 0}, {0, -1}, {-1, -1}, {1, -1}, {-1, 0}, {1, 0}},
+	RotationL: {{0, 0}, {-1, 0}, {-1, 2}, {-1, 1}, {0, 2}, {0, 1}},
+}
+
 type Piece struct {
 	Point    `json:"pp,omitempty"`
 	Mino     `json:"pm,omitempty"`
@@ -62,12 +108,18 @@ type Piece struct {
 	Rotation int   `json:"pr,omitempty"`
 
 	original  Mino
+	pieceType PieceType
 	pivotsCW  []Point
 	pivotsCCW []Point
 	resets    int
 	lastReset time.Time
+	landStart time.Time // Time the piece began landing
+	lowest    int       // Lowest row reached while landing
 	landing   bool
 	landed    bool
+	rotated   bool // Last successful action was a rotation
+	kick      int  // Offset index of the last rotation
+	halfTurn  bool // Last rotation was 180 degrees
 
 	sync.Mutex `json:"-"`
 }
@@ -106,16 +158,53 @@ func NewPiece(m Mino, loc Point) *Piece {
 		p.Solid = BlockSolidZ
 		p.Ghost = BlockGhostZ
 	default:
-		p.Solid = BlockSolidO
-		p.Ghost = BlockGhostO
+		p.Solid = pieceBlock(m)
+		p.Ghost = p.Solid.Ghost()
 	}
 
-	p.pivotsCW = AllRotationPivotsCW[pieceType]
-	p.pivotsCCW = AllRotationPivotsCCW[pieceType]
+	p.pieceType = pieceType
+	if pieceType == PieceUnknown {
+		p.pivotsCW, p.pivotsCCW = generatePivots(m)
+	} else {
+		p.pivotsCW = AllRotationPivotsCW[pieceType]
+		p.pivotsCCW = AllRotationPivotsCCW[pieceType]
+	}
 
 	return p
 }
 
+// generatePivots returns the clockwise and counter-clockwise rotation pivots
+// of each rotation state of a mino. Minos are rotated around the center of
+// their initial bounding box. When that center is not on a cell or a corner,
+// it is moved down half a cell, as with the I tetromino.
+func generatePivots(m Mino) ([]Point, []Point) {
+	var (
+		w, h      = m.Size()
+		c         = Point{w - 1, h - 1} // Center, in half cells
+		pivotsCW  = make([]Point, RotationStates)
+		pivotsCCW = make([]Point, RotationStates)
+	)
+	if (c.X+c.Y)%2 != 0 {
+		c.Y--
+	}
+
+	state := make(Mino, len(m))
+	copy(state, m)
+	for r := 0; r < RotationStates; r++ {
+		minX, minY := state.minCoords()
+
+		pivotsCW[r] = Point{(c.X+c.Y)/2 - minX, (c.Y-c.X)/2 - minY}
+		pivotsCCW[r] = Point{(c.X-c.Y)/2 - minX, (c.X+c.Y)/2 - minY}
+
+		for i, p := range state {
+			state[i] = Point{p.X*2 - c.X, p.Y*2 - c.Y}.Rotate90()
+			state[i] = Point{(state[i].X + c.X) / 2, (state[i].Y + c.Y) / 2}
+		}
+	}
+
+	return pivotsCW, pivotsCCW
+}
+
 // Rotate returns the new mino of a piece when a rotation is applied
 func (p *Piece) Rotate(rotations int, direction int) Mino {
 	p.Lock()
@@ -141,14 +230,16 @@ func (p *Piece) Rotate(rotations int, direction int) Mino {
 		x, y          int
 	)
 	for j := 0; j < rotations; j++ {
-		if direction == 0 {
-			rotationPivot += j
-		} else {
-			rotationPivot -= j
-		}
-		if rotationPivot < 0 {
-			rotationPivot += RotationStates
+		if j > 0 {
+			// Continue from the rotation state reached so far
+			if direction == 0 {
+				rotationPivot++
+			} else {
+				rotationPivot--
+			}
+			newMino = newMino.Origin()
 		}
+		rotationPivot = (rotationPivot + RotationStates) % RotationStates
 
 		if (rotationPivot == 3 && direction == 0) || (rotationPivot == 1 && direction == 1) {
 			newMino = p.original
@@ -171,16 +262,71 @@ func (p *Piece) Rotate(rotations int, direction int) Mino {
 	return newMino
 }
 
-func (p *Piece) ApplyReset() {
+// Offsets returns the offsets to try in order when a rotation is applied
+func (p *Piece) Offsets(rs RotationSystem, rotations int, direction int) []Point {
 	p.Lock()
 	defer p.Unlock()
 
-	if !p.landing || p.resets >= 15 {
+	if rotations == 2 && (rs != RotationSRS || p.pieceType == PieceUnknown) {
+		return Offsets180
+	} else if rs != RotationSRS || (rotations != 1 && rotations != 2) {
+		return AllOffsets
+	}
+
+	var offsets [][]Point
+	switch {
+	case p.pieceType == PieceUnknown:
+		return AllOffsets
+	case p.pieceType == PieceO:
+		return []Point{{0, 0}}
+	case rotations == 2:
+		offsets = SRSOffsets180
+	case p.pieceType == PieceI:
+		if direction == 0 {
+			offsets = SRSOffsetsICW
+		} else {
+			offsets = SRSOffsetsICCW
+		}
+	default:
+		if direction == 0 {
+			offsets = SRSOffsetsCW
+		} else {
+			offsets = SRSOffsetsCCW
+		}
+	}
+
+	return offsets[p.Rotation]
+}
+
+// ApplyReset restarts the lock delay of a landing piece which is moved or
+// rotated into the specified row at the specified time, as permitted by the
+// rules.
+func (p *Piece) ApplyReset(r Rules, y int, now time.Time) {
+	p.Lock()
+	defer p.Unlock()
+
+	if !p.landing {
 		return
 	}
 
+	switch r.LockReset {
+	case LockResetInfinite:
+	case LockResetStep:
+		if y >= p.lowest {
+			return
+		}
+
+		p.lowest = y
+	case LockResetNone:
+		return
+	default:
+		if p.resets >= r.Resets() {
+			return
+		}
+	}
+
 	p.resets++
-	p.lastReset = time.Now()
+	p.lastReset = now
 }
 
 func (p *Piece) ApplyRotation(rotations int, direction int) {
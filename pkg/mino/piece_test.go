@@ -1,5 +1,11 @@
 package mino
 
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
 type PieceTestData struct {
 	R0 string
 	RR string
@@ -64,3 +70,131 @@ func TestPiece(t *testing.T) {
 	}
 }
 */
+
+func TestGeneratePivots(t *testing.T) {
+	t.Parallel()
+
+	for _, tetromino := range []string{TetrominoI, TetrominoO, TetrominoJ, TetrominoL, TetrominoS, TetrominoT, TetrominoZ} {
+		p := NewPiece(NewMino(tetromino), Point{0, 0})
+		pivotsCW, pivotsCCW := generatePivots(p.Mino)
+
+		// The final clockwise and first counter-clockwise pivots are unused
+		for r := 0; r < RotationStates; r++ {
+			if r != RotationL && pivotsCW[r] != p.pivotsCW[r] {
+				t.Errorf("failed to generate pivots of %s: expected CW pivot %s for state %d, got %s", tetromino, p.pivotsCW[r], r, pivotsCW[r])
+			}
+			if r != RotationR && pivotsCCW[r] != p.pivotsCCW[r] {
+				t.Errorf("failed to generate pivots of %s: expected CCW pivot %s for state %d, got %s", tetromino, p.pivotsCCW[r], r, pivotsCCW[r])
+			}
+		}
+	}
+}
+
+func TestRotatePentomino(t *testing.T) {
+	t.Parallel()
+
+	for _, pentomino := range []string{PentominoF, PentominoI, PentominoX, PentominoW} {
+		p := NewPiece(NewMino(pentomino), Point{0, 0})
+
+		for direction := 0; direction <= 1; direction++ {
+			states := make([]string, RotationStates)
+			for r := 0; r < RotationStates; r++ {
+				states[r] = p.Mino.String()
+
+				p.Mino = p.Rotate(1, direction)
+				p.ApplyRotation(1, direction)
+			}
+
+			if p.Mino.String() != states[0] {
+				t.Errorf("failed to rotate %s in direction %d: expected %s after a full turn, got %s", pentomino, direction, states[0], p.Mino)
+			}
+			if states[1] == states[0] && pentomino != PentominoX {
+				t.Errorf("failed to rotate %s in direction %d: mino did not change", pentomino, direction)
+			}
+		}
+	}
+}
+
+func TestPieceBlocks(t *testing.T) {
+	t.Parallel()
+
+	found := make(map[Block]string)
+	for rank := 1; rank <= 5; rank++ {
+		minos, err := Generate(rank)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, m := range minos {
+			p := NewPiece(m, Point{0, 0})
+			if other, ok := found[p.Solid]; ok {
+				t.Errorf("failed to assign distinct blocks: %s and %s both use %d", other, m, p.Solid)
+			}
+			if p.Ghost == p.Solid {
+				t.Errorf("failed to assign ghost block to %s", m)
+			}
+
+			found[p.Solid] = m.String()
+		}
+	}
+}
+
+func TestOffsets180(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		rs       RotationSystem
+		mino     string
+		expected []Point
+	}{
+		{RotationClassic, TetrominoT, Offsets180},
+		{RotationClassic, TetrominoO, Offsets180},
+		{RotationClassic, PentominoF, Offsets180},
+		{RotationSRS, TetrominoT, SRSOffsets180[Rotation0]},
+		{RotationSRS, TetrominoO, []Point{{0, 0}}},
+		{RotationSRS, PentominoF, Offsets180},
+	}
+
+	for i, c := range testCases {
+		p := NewPiece(NewMino(c.mino), Point{0, 0})
+
+		offsets := p.Offsets(c.rs, 2, 0)
+		if fmt.Sprint(offsets) != fmt.Sprint(c.expected) {
+			t.Errorf("case %d: failed to get %s 180 degree offsets of %s: expected %v, got %v", i, c.rs, c.mino, c.expected, offsets)
+		}
+	}
+}
+
+func TestApplyReset(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		rules  Rules
+		rows   []int
+		resets int
+	}{
+		{Rules{}, []int{5, 5, 5}, 3},
+		{Rules{ResetLimit: 2}, []int{5, 5, 5}, 2},
+		{Rules{LockReset: LockResetInfinite}, make([]int, DefaultResetLimit+5), DefaultResetLimit + 5},
+		{Rules{LockReset: LockResetStep}, []int{5, 4, 4, 6, 2}, 2},
+		{Rules{LockReset: LockResetNone}, []int{5, 4, 3}, 0},
+	}
+
+	for i, tc := range testCases {
+		p := NewPiece(NewMino(TetrominoT), Point{3, 5})
+		p.ApplyReset(tc.rules, 4, time.Time{})
+		if p.resets != 0 {
+			t.Errorf("case %d: failed to ignore reset before landing: got %d resets", i, p.resets)
+		}
+
+		p.landing = true
+		p.lowest = p.Y
+		for _, y := range tc.rows {
+			p.ApplyReset(tc.rules, y, time.Time{})
+		}
+
+		if p.resets != tc.resets {
+			t.Errorf("case %d: failed to apply %s resets: expected %d, got %d", i, tc.rules.LockReset, tc.resets, p.resets)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package mino
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+)
+
+type PuzzleGoal int
+
+const (
+	GoalLines        PuzzleGoal = iota // Clear a number of lines
+	GoalPerfectClear                   // Clear every block
+	GoalTSpinDouble                    // Clear two lines with a T-spin
+	GoalSurvive                        // Place every piece without topping out
+)
+
+func (g PuzzleGoal) String() string {
+	switch g {
+	case GoalLines:
+		return "Clear lines"
+	case GoalPerfectClear:
+		return "Perfect clear"
+	case GoalTSpinDouble:
+		return "T-spin double"
+	case GoalSurvive:
+		return "Survive"
+	default:
+		return "Unknown"
+	}
+}
+
+// Puzzle is a starting board, a fixed sequence of pieces and a goal.
+//
+// Puzzles are stored as text, one field per line:
+//
+//	# Comment
+//	name: Tutorial
+//	goal: lines 2
+//	pieces: TIO
+//	board:
+//	..........
+//	XXXX..XXXX
+//
+// The goal is one of lines N, perfect-clear, tspin-double or survive. Pieces
+// are tetromino letters. The board is listed from the top row down, using . for
+// empty blocks, X for garbage and tetromino letters for colored blocks, or may
+// be supplied as a fumen on the same line.
+type Puzzle struct {
+	Name   string
+	Goal   PuzzleGoal
+	Lines  int // Lines to clear
+	Pieces []Mino
+
+	Board []Block // Indexed by I with a width of Width, starting from the bottom row
+	Width int
+}
+
+var puzzleTetrominoes = map[rune]string{
+	'I': TetrominoI,
+	'O': TetrominoO,
+	'T': TetrominoT,
+	'S': TetrominoS,
+	'Z': TetrominoZ,
+	'J': TetrominoJ,
+	'L': TetrominoL,
+}
+
+var puzzleBlocks = map[rune]Block{
+	'.': BlockNone,
+	'X': BlockGarbage,
+	'I': BlockSolidI,
+	'O': BlockSolidO,
+	'T': BlockSolidT,
+	'S': BlockSolidS,
+	'Z': BlockSolidZ,
+	'J': BlockSolidJ,
+	'L': BlockSolidL,
+}
+
+// LoadPuzzle reads a puzzle from the specified file.
+func LoadPuzzle(path string) (*Puzzle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open puzzle: %s", err)
+	}
+	defer f.Close()
+
+	return ParsePuzzle(f)
+}
+
+// ParsePuzzle reads a puzzle.
+func ParsePuzzle(r io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+
+	var (
+		rows    []string
+		inBoard bool
+		line    int
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		split := strings.SplitN(text, ":", 2)
+		if len(split) == 1 {
+			if !inBoard {
+				return nil, fmt.Errorf("failed to parse puzzle on line %d: expected field", line)
+			}
+
+			rows = append(rows, text)
+			continue
+		}
+		inBoard = false
+
+		key, value := strings.ToLower(strings.TrimSpace(split[0])), strings.TrimSpace(split[1])
+		switch key {
+		case "name":
+			p.Name = value
+		case "goal":
+			goal := strings.Fields(strings.ToLower(value))
+			if len(goal) == 0 {
+				return nil, fmt.Errorf("failed to parse puzzle on line %d: no goal", line)
+			}
+
+			switch goal[0] {
+			case "lines":
+				p.Goal = GoalLines
+				p.Lines = 1
+				if len(goal) > 1 {
+					lines, err := strconv.Atoi(goal[1])
+					if err != nil || lines < 1 {
+						return nil, fmt.Errorf("failed to parse puzzle on line %d: invalid lines %s", line, goal[1])
+					}
+					p.Lines = lines
+				}
+			case "perfect-clear":
+				p.Goal = GoalPerfectClear
+			case "tspin-double":
+				p.Goal = GoalTSpinDouble
+			case "survive":
+				p.Goal = GoalSurvive
+			default:
+				return nil, fmt.Errorf("failed to parse puzzle on line %d: unknown goal %s", line, goal[0])
+			}
+		case "pieces":
+			for _, c := range strings.ToUpper(value) {
+				if c == ' ' || c == ',' {
+					continue
+				}
+
+				t, ok := puzzleTetrominoes[c]
+				if !ok {
+					return nil, fmt.Errorf("failed to parse puzzle on line %d: unknown piece %c", line, c)
+				}
+				p.Pieces = append(p.Pieces, NewMino(t))
+			}
+		case "board":
+			if value == "" {
+				inBoard = true
+				continue
+			}
+
+			board, err := DecodeFumen(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse puzzle on line %d: failed to decode fumen: %s", line, err)
+			}
+			p.Board = board
+			p.Width = fumenWidth
+		default:
+			return nil, fmt.Errorf("failed to parse puzzle on line %d: unknown field %s", line, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read puzzle: %s", err)
+	}
+
+	if len(rows) > 0 {
+		p.Width = len(rows[0])
+		p.Board = make([]Block, p.Width*len(rows))
+		for i, row := range rows {
+			if len(row) != p.Width {
+				return nil, fmt.Errorf("failed to parse puzzle: board row %d is %d blocks wide, expected %d", i+1, len(row), p.Width)
+			}
+
+			y := len(rows) - 1 - i
+			for x, c := range strings.ToUpper(row) {
+				b, ok := puzzleBlocks[c]
+				if !ok {
+					return nil, fmt.Errorf("failed to parse puzzle: unknown block %c on board row %d", c, i+1)
+				}
+				p.Board[I(x, y, p.Width)] = b
+			}
+		}
+	}
+
+	if len(p.Pieces) == 0 {
+		return nil, errors.New("failed to parse puzzle: no pieces")
+	}
+
+	return p, nil
+}
+
+// GoalString returns a description of the goal of the puzzle.
+func (p *Puzzle) GoalString() string {
+	switch p.Goal {
+	case GoalLines:
+		if p.Lines == 1 {
+			return "Clear 1 line"
+		}
+		return fmt.Sprintf("Clear %d lines", p.Lines)
+	case GoalSurvive:
+		return "Place every piece"
+	default:
+		return p.Goal.String()
+	}
+}
+
+// NewBag returns a bag which deals the pieces of the puzzle in order.
+func (p *Puzzle) NewBag(width int) (*Bag, error) {
+	return NewSequenceBag(p.Pieces, width)
+}
+
+// LoadPuzzle replaces the blocks of the matrix with the board of a puzzle and
+// begins tracking progress toward its goal.
+func (m *Matrix) LoadPuzzle(p *Puzzle) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if p.Board != nil && p.Width != m.W {
+		return fmt.Errorf("puzzle is %d blocks wide, expected %d", p.Width, m.W)
+	}
+	for i := len(m.M); i < len(p.Board); i++ {
+		if p.Board[i] != BlockNone {
+			return errors.New("puzzle exceeds matrix height")
+		}
+	}
+
+	for i := range m.M {
+		m.M[i] = BlockNone
+		if i < len(p.Board) {
+			m.M[i] = p.Board[i]
+		}
+	}
+
+	m.Puzzle = p
+	m.puzzlePlaced = 0
+	m.puzzleLines = 0
+
+	m.Draw()
+
+	return nil
+}
+
+// puzzleLanded records a piece landing in a puzzle and returns whether the
+// puzzle has ended. The puzzle ends when its goal is met or every piece has
+// been placed. The puzzle is failed when its pieces run out before that.
+func (m *Matrix) puzzleLanded(cleared int, spin SpinType, perfectClear bool) bool {
+	m.puzzlePlaced++
+	m.puzzleLines += cleared
+
+	solved := false
+	switch m.Puzzle.Goal {
+	case GoalLines:
+		solved = m.puzzleLines >= m.Puzzle.Lines
+	case GoalPerfectClear:
+		solved = perfectClear
+	case GoalTSpinDouble:
+		solved = spin == SpinFull && cleared == 2
+	case GoalSurvive:
+		solved = m.puzzlePlaced >= len(m.Puzzle.Pieces)
+	}
+
+	if !solved && m.puzzlePlaced < len(m.Puzzle.Pieces) {
+		return false
+	}
+
+	m.endPuzzle(solved)
+
+	return true
+}
+
+// endPuzzle reports the result of the puzzle and ends the game. A solved board
+// is left as it is.
+func (m *Matrix) endPuzzle(solved bool) {
+	m.Event <- &event.PuzzleEvent{Solved: solved}
+
+	if solved {
+		m.finish()
+	}
+
+	m.Event <- &event.GameOverEvent{}
+}
@@ -0,0 +1,146 @@
+package mino
+
+import (
+	"strings"
+	"testing"
+
+	"code.rocket9labs.com/tslocum/netris/pkg/event"
+)
+
+func TestPuzzle(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		puzzle string
+		solved bool
+	}{
+		{"name: Well\ngoal: lines 1\npieces: I\nboard:\nXXXXXX....", true},
+		{"goal: lines\npieces: IO\nboard:\nXXXXX.....", false},
+		{"# Perfect clear\ngoal: perfect-clear\npieces: I\nboard:\n..........\nXXXXXX....", true},
+		{"goal: survive\npieces: I, I\nboard: v115@bhJ8JeAgH", true},
+	}
+
+	for i, tc := range testCases {
+		p, err := ParsePuzzle(strings.NewReader(tc.puzzle))
+		if err != nil {
+			t.Fatalf("case %d: failed to parse puzzle: %s", i, err)
+		}
+
+		ev := make(chan interface{}, 10)
+		m := NewMatrix(10, 20, 4, 1, ev, nil, MatrixStandard)
+
+		err = m.LoadPuzzle(p)
+		if err != nil {
+			t.Fatalf("case %d: failed to load puzzle: %s", i, err)
+		}
+
+		bag, err := p.NewBag(m.W)
+		if err != nil {
+			t.Fatalf("case %d: failed to create bag: %s", i, err)
+		}
+		m.AttachBag(bag)
+
+		if !m.TakePiece() {
+			t.Fatalf("case %d: failed to take piece", i)
+		}
+
+		for range p.Pieces {
+			for m.MovePiece(1, 0) {
+			}
+			m.HardDropPiece()
+		}
+
+		var solved, ended, gameOver bool
+		for len(ev) > 0 {
+			switch e := (<-ev).(type) {
+			case *event.PuzzleEvent:
+				solved = e.Solved
+				ended = true
+			case *event.GameOverEvent:
+				gameOver = true
+			}
+		}
+		if !ended || !gameOver {
+			t.Errorf("case %d: failed to end puzzle", i)
+		} else if solved != tc.solved {
+			t.Errorf("case %d: failed to detect puzzle result: expected solved %v, got %v", i, tc.solved, solved)
+		}
+	}
+
+	for _, invalid := range []string{"", "goal: lines\nboard:\n....", "pieces: Q", "goal: win\npieces: T", "pieces: T\nboard:\n...\n....", "pieces: T\nboard:\n.?."} {
+		if _, err := ParsePuzzle(strings.NewReader(invalid)); err == nil {
+			t.Errorf("failed to reject invalid puzzle %q", invalid)
+		}
+	}
+}
+
+func TestPuzzleHold(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParsePuzzle(strings.NewReader("goal: lines 4\npieces: TIO"))
+	if err != nil {
+		t.Fatalf("failed to parse puzzle: %s", err)
+	}
+
+	ev := make(chan interface{}, 10)
+	m := NewMatrix(10, 20, 4, 1, ev, nil, MatrixStandard)
+
+	err = m.LoadPuzzle(p)
+	if err != nil {
+		t.Fatalf("failed to load puzzle: %s", err)
+	}
+
+	bag, err := p.NewBag(m.W)
+	if err != nil {
+		t.Fatalf("failed to create bag: %s", err)
+	}
+	m.AttachBag(bag)
+
+	if !m.TakePiece() {
+		t.Fatal("failed to take piece")
+	}
+
+	if !m.HoldPiece() {
+		t.Fatal("failed to hold piece")
+	} else if m.Hold == nil || m.Hold.Solid != BlockSolidT {
+		t.Fatal("failed to hold piece: expected T piece")
+	} else if m.P == nil || m.P.Solid != BlockSolidI {
+		t.Fatal("failed to deal piece after hold: expected I piece")
+	}
+
+	m.HardDropPiece()
+	m.HardDropPiece()
+
+	if m.P == nil || m.P.Solid != BlockSolidT {
+		t.Fatal("failed to play held piece after the last piece: expected T piece")
+	} else if m.Hold != nil {
+		t.Error("failed to play held piece after the last piece: hold box is not empty")
+	}
+
+	for len(ev) > 0 {
+		switch (<-ev).(type) {
+		case *event.PuzzleEvent, *event.GameOverEvent:
+			t.Fatal("failed to play held piece after the last piece: puzzle ended")
+		}
+	}
+
+	m.HardDropPiece()
+
+	if next := bag.Peek(3); len(next) != 0 {
+		t.Fatalf("failed to end piece sequence: expected no pieces, got %v", next)
+	}
+
+	var solved, ended, gameOver bool
+	for len(ev) > 0 {
+		switch e := (<-ev).(type) {
+		case *event.PuzzleEvent:
+			solved = e.Solved
+			ended = true
+		case *event.GameOverEvent:
+			gameOver = true
+		}
+	}
+	if !ended || solved || !gameOver {
+		t.Errorf("failed to fail puzzle after running out of pieces: ended %v, solved %v, game over %v", ended, solved, gameOver)
+	}
+}
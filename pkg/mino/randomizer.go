@@ -0,0 +1,174 @@
+package mino
+
+import (
+	"math/rand"
+)
+
+// Randomizer selects the order in which minos are played.
+type Randomizer interface {
+	// Next returns the next mino, or nil once a fixed sequence is exhausted.
+	Next() Mino
+}
+
+type RandomizerType int
+
+const (
+	RandomizerBag     RandomizerType = iota // Each mino once per bag
+	RandomizerBag14                         // Each mino twice per bag
+	RandomizerRandom                        // Each mino is equally likely
+	RandomizerHistory                       // Recently played minos are less likely
+)
+
+func (r RandomizerType) String() string {
+	switch r {
+	case RandomizerBag:
+		return "Bag"
+	case RandomizerBag14:
+		return "14-Bag"
+	case RandomizerRandom:
+		return "Random"
+	case RandomizerHistory:
+		return "History"
+	default:
+		return "Unknown"
+	}
+}
+
+// NewRandomizer returns a randomizer of the specified type. The sequence of
+// minos is determined by the supplied source of randomness.
+func NewRandomizer(t RandomizerType, minos []Mino, r *rand.Rand) Randomizer {
+	switch t {
+	case RandomizerBag14:
+		return newBagRandomizer(minos, 2, r)
+	case RandomizerRandom:
+		return &randomRandomizer{minos: minos, r: r}
+	case RandomizerHistory:
+		return newHistoryRandomizer(minos, r)
+	default:
+		return newBagRandomizer(minos, 1, r)
+	}
+}
+
+// bagRandomizer deals each mino a fixed number of times from a shuffled bag
+// before the bag is refilled.
+type bagRandomizer struct {
+	original []Mino
+	minos    []Mino
+	i        int
+	r        *rand.Rand
+}
+
+func newBagRandomizer(minos []Mino, copies int, r *rand.Rand) *bagRandomizer {
+	b := &bagRandomizer{r: r}
+	for i := 0; i < copies; i++ {
+		b.original = append(b.original, minos...)
+	}
+	b.minos = make([]Mino, len(b.original))
+
+	b.shuffle()
+
+	return b
+}
+
+func (b *bagRandomizer) Next() Mino {
+	m := b.minos[b.i]
+
+	if b.i == len(b.minos)-1 {
+		b.shuffle()
+
+		b.i = 0
+	} else {
+		b.i++
+	}
+
+	return m
+}
+
+func (b *bagRandomizer) shuffle() {
+	copy(b.minos, b.original)
+
+	b.r.Shuffle(len(b.minos), func(i, j int) { b.minos[i], b.minos[j] = b.minos[j], b.minos[i] })
+}
+
+// randomRandomizer selects each mino independently.
+type randomRandomizer struct {
+	minos []Mino
+	r     *rand.Rand
+}
+
+func (r *randomRandomizer) Next() Mino {
+	return r.minos[r.r.Intn(len(r.minos))]
+}
+
+const (
+	historyLength = 4 // Number of recent minos remembered
+	historyRolls  = 6 // Attempts to select a mino not recently played
+)
+
+// historyRandomizer rerolls minos which were recently played, as in TGM. When
+// playing tetrominoes the history starts with S and Z pieces, which prevents
+// them from being dealt first.
+type historyRandomizer struct {
+	minos   []Mino
+	history []string
+	r       *rand.Rand
+}
+
+func newHistoryRandomizer(minos []Mino, r *rand.Rand) *historyRandomizer {
+	h := &historyRandomizer{minos: minos, r: r}
+
+	for _, m := range minos {
+		switch s := m.Canonical().String(); s {
+		case TetrominoS, TetrominoZ:
+			h.history = append(h.history, s, s)
+		}
+	}
+
+	return h
+}
+
+func (h *historyRandomizer) Next() Mino {
+	var m Mino
+	for roll := 0; roll < historyRolls; roll++ {
+		m = h.minos[h.r.Intn(len(h.minos))]
+		if !h.recent(m) {
+			break
+		}
+	}
+
+	h.history = append(h.history, m.Canonical().String())
+	if len(h.history) > historyLength {
+		h.history = h.history[len(h.history)-historyLength:]
+	}
+
+	return m
+}
+
+func (h *historyRandomizer) recent(m Mino) bool {
+	s := m.Canonical().String()
+	for _, played := range h.history {
+		if played == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sequenceRandomizer deals minos in a fixed order, dealing nothing once every
+// mino has been dealt.
+type sequenceRandomizer struct {
+	minos []Mino
+	i     int
+}
+
+func (s *sequenceRandomizer) Next() Mino {
+	if s.i == len(s.minos) {
+		return nil
+	}
+
+	m := s.minos[s.i]
+	s.i++
+
+	return m
+}
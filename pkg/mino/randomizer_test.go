@@ -0,0 +1,63 @@
+package mino
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRandomizer(t *testing.T) {
+	t.Parallel()
+
+	minos, err := Generate(4)
+	if err != nil {
+		t.Fatalf("failed to generate minos: %s", err)
+	}
+
+	for _, randomizer := range []RandomizerType{RandomizerBag, RandomizerBag14, RandomizerRandom, RandomizerHistory} {
+		randomizer := randomizer // Capture
+
+		t.Run(fmt.Sprintf("Randomizer=%s", randomizer), func(t *testing.T) {
+			t.Parallel()
+
+			a, err := NewBag(7, minos, 10, randomizer)
+			if err != nil {
+				t.Fatalf("failed to create bag: %s", err)
+			}
+
+			b, err := NewBag(7, minos, 10, randomizer)
+			if err != nil {
+				t.Fatalf("failed to create bag: %s", err)
+			}
+
+			taken := make(map[string]int)
+			for i := 0; i < len(minos)*2; i++ {
+				ma, mb := a.Take(), b.Take()
+				if ma.String() != mb.String() {
+					t.Fatalf("failed to randomize deterministically: mino %d differs: %s and %s", i, ma, mb)
+				}
+
+				taken[ma.String()]++
+			}
+
+			switch randomizer {
+			case RandomizerBag, RandomizerBag14:
+				for _, m := range minos {
+					if taken[m.String()] != 2 {
+						t.Errorf("failed to randomize: expected mino %s to be taken twice, got %d", m, taken[m.String()])
+					}
+				}
+			case RandomizerHistory:
+				for seed := int64(1); seed <= 10; seed++ {
+					c, err := NewBag(seed, minos, 10, randomizer)
+					if err != nil {
+						t.Fatalf("failed to create bag: %s", err)
+					}
+
+					if s := c.Take().String(); s == TetrominoS || s == TetrominoZ {
+						t.Errorf("failed to randomize: first mino of seed %d was %s", seed, s)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package mino
+
+import "time"
+
+type RotationSystem int
+
+const (
+	RotationClassic RotationSystem = iota // netris classic
+	RotationSRS                           // Super Rotation System
+)
+
+func (r RotationSystem) String() string {
+	switch r {
+	case RotationClassic:
+		return "Classic"
+	case RotationSRS:
+		return "SRS"
+	default:
+		return "Unknown"
+	}
+}
+
+type Gravity int
+
+const (
+	GravityStatic Gravity = iota // Fixed fall time
+	GravityLevel                 // Level increases as lines are cleared
+	GravityTime                  // Level increases over time
+)
+
+func (g Gravity) String() string {
+	switch g {
+	case GravityStatic:
+		return "Static"
+	case GravityLevel:
+		return "Level"
+	case GravityTime:
+		return "Time"
+	default:
+		return "Unknown"
+	}
+}
+
+type GarbageStyle int
+
+const (
+	GarbageCheese GarbageStyle = iota // Each attack starts with a new hole
+	GarbageClean                      // Each attack continues the hole of the previous attack
+)
+
+func (g GarbageStyle) String() string {
+	switch g {
+	case GarbageCheese:
+		return "Cheese"
+	case GarbageClean:
+		return "Clean"
+	default:
+		return "Unknown"
+	}
+}
+
+type GarbageMessiness int
+
+const (
+	MessinessRandom GarbageMessiness = iota // Every line has a random hole
+	MessinessChance                         // The hole may change between lines of an attack
+	MessinessAttack                         // Every line of an attack has the same hole
+)
+
+func (g GarbageMessiness) String() string {
+	switch g {
+	case MessinessRandom:
+		return "Random"
+	case MessinessChance:
+		return "Chance"
+	case MessinessAttack:
+		return "Attack"
+	default:
+		return "Unknown"
+	}
+}
+
+// Percent chance the hole changes between lines of an attack
+const (
+	DefaultGarbageChance = 30
+	MinGarbageChance     = 1
+	MaxGarbageChance     = 99
+)
+
+// Matrix dimensions
+const (
+	DefaultWidth  = 10
+	DefaultHeight = 20
+	MinWidth      = 4
+	MaxWidth      = 20
+	MinHeight     = 8
+	MaxHeight     = 40
+)
+
+const (
+	DefaultPerfectClearGarbage = 10
+	MaxPerfectClearGarbage     = 20
+
+	PerfectClearNone = -1 // No perfect clear garbage bonus
+)
+
+// Lock delay in milliseconds
+const (
+	DefaultLockDelay = 500
+	MinLockDelay     = 100
+	MaxLockDelay     = 5000
+)
+
+// Lock delay resets allowed with move reset
+const (
+	DefaultResetLimit = 15
+	MaxResetLimit     = 99
+)
+
+type LockReset int
+
+const (
+	LockResetMove     LockReset = iota // Moving or rotating restarts lock delay, up to the reset limit
+	LockResetInfinite                  // Moving or rotating always restarts lock delay
+	LockResetStep                      // Falling to a lower row restarts lock delay
+	LockResetNone                      // Lock delay is never restarted
+)
+
+func (l LockReset) String() string {
+	switch l {
+	case LockResetMove:
+		return "Move"
+	case LockResetInfinite:
+		return "Infinite"
+	case LockResetStep:
+		return "Step"
+	case LockResetNone:
+		return "None"
+	default:
+		return "Unknown"
+	}
+}
+
+// Rules are the game mechanics which may vary between games. The zero value
+// is netris classic.
+type Rules struct {
+	RotationSystem RotationSystem   `json:"rs,omitempty"`
+	Gravity        Gravity          `json:"gv,omitempty"`
+	Randomizer     RandomizerType   `json:"rz,omitempty"`
+	Garbage        GarbageStyle     `json:"gb,omitempty"`
+	Messiness      GarbageMessiness `json:"gm,omitempty"`
+	GarbageChance  int              `json:"gc,omitempty"` // Percent
+	Width          int              `json:"w,omitempty"`
+	Height         int              `json:"h,omitempty"`  // Always even
+	PerfectClear   int              `json:"pc,omitempty"` // Bonus garbage lines
+	LockDelay      int              `json:"ld,omitempty"` // Milliseconds
+	LockReset      LockReset        `json:"lr,omitempty"`
+	ResetLimit     int              `json:"rl,omitempty"` // Move resets allowed
+}
+
+// HoleChance returns the percent chance the hole changes between lines of an
+// attack when messiness is MessinessChance.
+func (r Rules) HoleChance() int {
+	if r.GarbageChance == 0 {
+		return DefaultGarbageChance
+	}
+
+	return r.GarbageChance
+}
+
+// Size returns the width and height of the matrix.
+func (r Rules) Size() (int, int) {
+	w, h := r.Width, r.Height
+	if w == 0 {
+		w = DefaultWidth
+	}
+	if h == 0 {
+		h = DefaultHeight
+	}
+
+	return w, h
+}
+
+// PerfectClearGarbage returns the number of bonus garbage lines sent when the
+// matrix is cleared completely.
+func (r Rules) PerfectClearGarbage() int {
+	switch r.PerfectClear {
+	case 0:
+		return DefaultPerfectClearGarbage
+	case PerfectClearNone:
+		return 0
+	default:
+		return r.PerfectClear
+	}
+}
+
+// LockDelayTime returns the time a landed piece may be moved before it locks.
+func (r Rules) LockDelayTime() time.Duration {
+	if r.LockDelay == 0 {
+		return DefaultLockDelay * time.Millisecond
+	}
+
+	return time.Duration(r.LockDelay) * time.Millisecond
+}
+
+// Resets returns the number of times lock delay may be restarted with move
+// reset.
+func (r Rules) Resets() int {
+	if r.ResetLimit == 0 {
+		return DefaultResetLimit
+	}
+
+	return r.ResetLimit
+}
+
+// Validate resets invalid rules to their default values.
+func (r *Rules) Validate() {
+	if r.RotationSystem < RotationClassic || r.RotationSystem > RotationSRS {
+		r.RotationSystem = RotationClassic
+	}
+	if r.Gravity < GravityStatic || r.Gravity > GravityTime {
+		r.Gravity = GravityStatic
+	}
+	if r.Randomizer < RandomizerBag || r.Randomizer > RandomizerHistory {
+		r.Randomizer = RandomizerBag
+	}
+	if r.Garbage < GarbageCheese || r.Garbage > GarbageClean {
+		r.Garbage = GarbageCheese
+	}
+	if r.Messiness < MessinessRandom || r.Messiness > MessinessAttack {
+		r.Messiness = MessinessRandom
+	}
+	if r.GarbageChance != 0 && (r.GarbageChance < MinGarbageChance || r.GarbageChance > MaxGarbageChance) {
+		r.GarbageChance = 0
+	}
+	if r.Width != 0 && (r.Width < MinWidth || r.Width > MaxWidth) {
+		r.Width = 0
+	}
+	if r.PerfectClear < PerfectClearNone || r.PerfectClear > MaxPerfectClearGarbage {
+		r.PerfectClear = 0
+	}
+	if r.LockDelay != 0 && (r.LockDelay < MinLockDelay || r.LockDelay > MaxLockDelay) {
+		r.LockDelay = 0
+	}
+	if r.LockReset < LockResetMove || r.LockReset > LockResetNone {
+		r.LockReset = LockResetMove
+	}
+	if r.ResetLimit < 0 || r.ResetLimit > MaxResetLimit {
+		r.ResetLimit = 0
+	}
+	r.Height -= r.Height % 2
+	if r.Height != 0 && (r.Height < MinHeight || r.Height > MaxHeight) {
+		r.Height = 0
+	}
+}
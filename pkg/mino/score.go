@@ -0,0 +1,72 @@
+package mino
+
+const (
+	ScoreSoftDrop = 1 // Points per cell
+	ScoreHardDrop = 2 // Points per cell
+	ScoreCombo    = 50
+
+	ScorePerfectClear = 2000
+)
+
+// LineClearScore returns the points awarded for clearing lines.
+func LineClearScore(lines int, spin SpinType) int {
+	switch spin {
+	case SpinFull:
+		switch lines {
+		case 0:
+			return 400
+		case 1:
+			return 800
+		case 2:
+			return 1200
+		default:
+			return 1600
+		}
+	case SpinMini:
+		switch lines {
+		case 0:
+			return 100
+		case 1:
+			return 200
+		default:
+			return 400
+		}
+	}
+
+	switch lines {
+	case 0:
+		return 0
+	case 1:
+		return 100
+	case 2:
+		return 300
+	case 3:
+		return 500
+	case 4:
+		return 800
+	default:
+		return 1000 + ((lines - 5) * 200)
+	}
+}
+
+// Difficult returns whether a line clear continues a back-to-back chain.
+func Difficult(lines int, spin SpinType) bool {
+	return lines >= 4 || (lines > 0 && spin != SpinNone)
+}
+
+// BackToBackGarbage returns the number of bonus garbage lines sent at a level
+// of a back-to-back chain. The first difficult line clear of a chain is level 0.
+func BackToBackGarbage(level int) int {
+	switch {
+	case level < 1:
+		return 0
+	case level < 3:
+		return 1
+	case level < 8:
+		return 2
+	case level < 24:
+		return 3
+	default:
+		return 4
+	}
+}
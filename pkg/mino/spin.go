@@ -0,0 +1,123 @@
+package mino
+
+type SpinType int
+
+const (
+	SpinNone SpinType = iota
+	SpinMini
+	SpinFull
+)
+
+func (s SpinType) String() string {
+	switch s {
+	case SpinMini:
+		return "Mini T-Spin"
+	case SpinFull:
+		return "T-Spin"
+	default:
+		return ""
+	}
+}
+
+// Final SRS offset, which always results in a full spin
+const srsFinalOffset = 4
+
+var lineClearNames = []string{"", "Single", "Double", "Triple"}
+
+// SpinName returns the name of a spin which cleared the specified number of lines.
+func SpinName(spin SpinType, lines int) string {
+	if spin == SpinNone {
+		return ""
+	} else if lines <= 0 || lines >= len(lineClearNames) {
+		return spin.String()
+	}
+
+	return spin.String() + " " + lineClearNames[lines]
+}
+
+// SpinGarbage returns the number of garbage lines sent by a line clear.
+func SpinGarbage(spin SpinType, lines int) int {
+	if lines <= 0 {
+		return 0
+	} else if spin == SpinFull {
+		return lines * 2
+	}
+
+	return lines - 1
+}
+
+// occupied returns whether a point is filled or out of bounds.
+func (m *Matrix) occupied(x int, y int) bool {
+	if x < 0 || x >= m.W || y < 0 || y >= m.H+m.B {
+		return true
+	}
+
+	return m.M[I(x, y, m.W)] != BlockNone
+}
+
+// detectSpin returns the type of spin performed when a piece lands at the
+// specified location. T pieces which were rotated into place are checked
+// using the 3-corner rule.
+func (m *Matrix) detectSpin(p *Piece, loc Point) SpinType {
+	if p.pieceType != PieceT || !p.rotated {
+		return SpinNone
+	}
+
+	var (
+		center Point
+		found  bool
+	)
+	for _, pt := range p.Mino {
+		neighbors := 0
+		for _, n := range []Point{{pt.X - 1, pt.Y}, {pt.X + 1, pt.Y}, {pt.X, pt.Y - 1}, {pt.X, pt.Y + 1}} {
+			if p.Mino.HasPoint(n) {
+				neighbors++
+			}
+		}
+
+		if neighbors == 3 {
+			center = pt
+			found = true
+			break
+		}
+	}
+	if !found {
+		return SpinNone
+	}
+
+	// Direction the piece is pointing
+	var direction Point
+	for _, pt := range p.Mino {
+		dx, dy := pt.X-center.X, pt.Y-center.Y
+		if (dx == 0) == (dy == 0) {
+			continue
+		}
+
+		if !p.Mino.HasPoint(Point{center.X - dx, center.Y - dy}) {
+			direction = Point{dx, dy}
+			break
+		}
+	}
+
+	cx, cy := center.X+loc.X, center.Y+loc.Y
+
+	var corners, front int
+	for _, c := range []Point{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		if !m.occupied(cx+c.X, cy+c.Y) {
+			continue
+		}
+
+		corners++
+		if (direction.X != 0 && c.X == direction.X) || (direction.Y != 0 && c.Y == direction.Y) {
+			front++
+		}
+	}
+
+	if corners < 3 {
+		return SpinNone
+	} else if front < 2 && !(m.Rules.RotationSystem == RotationSRS && p.kick == srsFinalOffset && !p.halfTurn) {
+		return SpinMini
+	}
+
+	return SpinFull
+}